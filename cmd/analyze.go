@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+// runAnalyze reports every turn of a saved game against the engine's best plays
+// `analyze GAME_ID [-json] [-plays N]`
+func runAnalyze(gameDB *scrabble.GameDB, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: analyze GAME_ID [-json] [-plays N]")
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	var opts scrabble.AnalysisOptions
+	var asJSON bool
+	options := args[1:]
+	for i := 0; i < len(options); i++ {
+		switch options[i] {
+		case "-json":
+			asJSON = true
+		case "-plays":
+			if i+1 == len(options) {
+				return fmt.Errorf("missing value for -plays")
+			}
+			i++
+			opts.Plays, err = strconv.Atoi(options[i])
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown option %q", options[i])
+		}
+	}
+
+	game, err := gameDB.GetGameByID(id)
+	if err != nil {
+		return err
+	}
+	analysis, err := scrabble.AnalyzeGame(game, opts)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		out, err := json.MarshalIndent(analysis, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	fmt.Println(analysis)
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+const duplicateUsage = "usage: duplicate [-seed S] [-rules NAME] PLAYER[:LEVEL]..."
+
+// runDuplicate plays a duplicate game at the terminal, players hand over the keyboard to submit their plays
+// `duplicate [-seed S] [-rules NAME] alice bob carol:hard` lists the players, a bot level after `:` makes a computer player
+func runDuplicate(gameDB *scrabble.GameDB, args []string) error {
+	var opts scrabble.GameOptions
+	var players []scrabble.PlayerRequest
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			name, level := args[i], ""
+			if at := strings.Index(name, ":"); at >= 0 {
+				name, level = name[:at], name[at+1:]
+			}
+			player := scrabble.PlayerRequest{Name: name}
+			if level != "" {
+				bot, ok := scrabble.BotLevels[level]
+				if !ok {
+					return fmt.Errorf("unknown bot level %q", level)
+				}
+				player.Bot = bot
+			}
+			players = append(players, player)
+			continue
+		}
+		if i+1 == len(args) {
+			return errors.New(duplicateUsage)
+		}
+		i++
+		switch args[i-1] {
+		case "-seed":
+			seed, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return err
+			}
+			opts.Seed = seed
+		case "-rules":
+			rules, err := scrabble.FindRuleSet(args[i])
+			if err != nil {
+				return err
+			}
+			opts.Rules = &rules
+		default:
+			return errors.New(duplicateUsage)
+		}
+	}
+
+	d, err := scrabble.NewDuplicate(players, opts)
+	if err != nil {
+		return err
+	}
+	playDuplicate(bufio.NewReader(os.Stdin), os.Stdout, d)
+	return nil
+}
+
+// playDuplicate runs every round, asking each human player for a play in turn
+// the screen is cleared between players so plays stay private until the round is scored
+func playDuplicate(reader *bufio.Reader, out io.Writer, d *scrabble.Duplicate) {
+	for !d.IsOver() {
+		for _, name := range d.Waiting() {
+			if bot := duplicatePlayer(d, name).Bot; bot != "" {
+				continue
+			}
+			fmt.Fprint(out, clearScreen)
+			fmt.Fprintf(out, "Round %v, pass the keyboard to %s and press enter", d.Round(), name)
+			reader.ReadString('\n')
+			fmt.Fprintln(out, d.Game().GetBoard())
+			fmt.Fprintf(out, "Rack: %s\nTiles Remaining: %v\n", d.Rack(), len(d.Game().Tiles.GetTiles()))
+			for {
+				fmt.Fprintf(out, "%s, enter your play (or pass): ", name)
+				input, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				play, err := d.Submit(name, input)
+				if err != nil {
+					fmt.Fprintf(out, "Could not submit play: %v\n", err)
+					continue
+				}
+				fmt.Fprintf(out, "Submitted %s, press enter", play)
+				reader.ReadString('\n')
+				break
+			}
+		}
+		fmt.Fprint(out, clearScreen)
+
+		err := d.SubmitBots()
+		if err != nil {
+			fmt.Fprintln(out, err)
+			return
+		}
+		round, err := d.EndRound()
+		if err != nil {
+			fmt.Fprintln(out, err)
+			return
+		}
+		fmt.Fprintln(out, round)
+		printDuplicateStandings(out, d)
+	}
+
+	fmt.Fprintln(out, d.Game().GetBoard())
+	fmt.Fprintln(out, "Game over")
+	printDuplicateStandings(out, d)
+}
+
+// printDuplicateStandings lists each player's score and their share of the top plays
+func printDuplicateStandings(out io.Writer, d *scrabble.Duplicate) {
+	top := d.TopScore()
+	fmt.Fprintf(out, "Standings (top %v)\n--------------------\n", top)
+	for _, p := range d.Standings() {
+		var share float64
+		if top > 0 {
+			share = float64(p.Score()) / float64(top) * 100
+		}
+		fmt.Fprintf(out, "%s: %v (%.1f%%)\n", p.Name, p.Score(), share)
+	}
+	fmt.Fprintln(out, "--------------------")
+}
+
+// duplicatePlayer finds a participant of the duplicate game by name
+func duplicatePlayer(d *scrabble.Duplicate, name string) scrabble.DuplicatePlayer {
+	for _, p := range d.Players() {
+		if p.Name == name {
+			return p
+		}
+	}
+	return scrabble.DuplicatePlayer{}
+}
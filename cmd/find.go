@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+// maxFindResults limits how many words are printed for a search
+const maxFindResults = 100
+
+const findUsage = "usage: find anagram|build|pattern|contains|starts LETTERS [-min N] [-max N] [-sort alpha|length|score]"
+
+// runFind searches the default dictionary, or the dictionary of the tile set given with `-tiles NAME`
+func runFind(gameDB *scrabble.GameDB, args []string) error {
+	ts := scrabble.EnglishTiles
+	var search []string
+	for i := 0; i < len(args); i++ {
+		if args[i] != "-tiles" || i+1 == len(args) {
+			search = append(search, args[i])
+			continue
+		}
+		i++
+		var err error
+		ts, err = scrabble.FindTileSet(args[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	dict, err := scrabble.LoadTileSetDictionary(ts.Dictionary, ts)
+	if err != nil {
+		return err
+	}
+	return findWords(os.Stdout, dict, search)
+}
+
+// findWords parses a search in the form `anagram AEINST? -min 7 -sort score` and prints the results
+func findWords(out io.Writer, dict scrabble.Dictionary, args []string) error {
+	if len(args) < 2 {
+		return errors.New(findUsage)
+	}
+
+	var query scrabble.WordQuery
+	switch args[0] {
+	case "anagram":
+		query.Anagram = args[1]
+	case "build":
+		query.Build = args[1]
+	case "pattern":
+		query.Pattern = args[1]
+	case "contains":
+		query.Contains = args[1]
+	case "starts":
+		query.StartsWith = args[1]
+	default:
+		return fmt.Errorf("unknown search %q", args[0])
+	}
+
+	options := args[2:]
+	if len(options)%2 != 0 {
+		return errors.New(findUsage)
+	}
+	for i := 0; i < len(options); i += 2 {
+		var err error
+		switch options[i] {
+		case "-min":
+			query.MinLength, err = strconv.Atoi(options[i+1])
+		case "-max":
+			query.MaxLength, err = strconv.Atoi(options[i+1])
+		case "-sort":
+			switch options[i+1] {
+			case scrabble.SortAlphabetical, scrabble.SortLength, scrabble.SortScore:
+				query.SortBy = options[i+1]
+			default:
+				err = errors.New(findUsage)
+			}
+		default:
+			err = fmt.Errorf("unknown option %q", options[i])
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	found := dict.Find(query)
+	for i, f := range found {
+		if i == maxFindResults {
+			fmt.Fprintf(out, "... %v more\n", len(found)-maxFindResults)
+			break
+		}
+		fmt.Fprintln(out, f)
+	}
+	fmt.Fprintf(out, "%v words found\n\n", len(found))
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+// maxImageSquareSize keeps the larger boards drawn within a few thousand pixels
+const maxImageSquareSize = 200
+
+// runExportImage draws the board of a saved game as an svg or png file
+// `export-image GAME_ID [TURN] [-o FILE] [-size PIXELS] [-no-highlight]`
+// the board after the latest turn is drawn when no turn is given, the format follows the file extension
+func runExportImage(gameDB *scrabble.GameDB, args []string) error {
+	usage := errors.New("usage: export-image GAME_ID [TURN] [-o FILE] [-size PIXELS] [-no-highlight]")
+	if len(args) < 1 {
+		return usage
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+	game, err := gameDB.GetGameByID(id)
+	if err != nil {
+		return err
+	}
+
+	turn := len(game.Turns)
+	var path string
+	var opts scrabble.ImageOptions
+	highlight := true
+	options := args[1:]
+	if len(options) > 0 && !strings.HasPrefix(options[0], "-") {
+		turn, err = strconv.Atoi(options[0])
+		if err != nil {
+			return err
+		}
+		options = options[1:]
+	}
+	for i := 0; i < len(options); i++ {
+		switch options[i] {
+		case "-no-highlight":
+			highlight = false
+		case "-o":
+			if i+1 == len(options) {
+				return fmt.Errorf("missing value for -o")
+			}
+			i++
+			path = options[i]
+		case "-size":
+			if i+1 == len(options) {
+				return fmt.Errorf("missing value for -size")
+			}
+			i++
+			opts.SquareSize, err = strconv.Atoi(options[i])
+			if err != nil {
+				return err
+			}
+			if opts.SquareSize < 1 || opts.SquareSize > maxImageSquareSize {
+				return fmt.Errorf("-size must be from 1 to %v pixels", maxImageSquareSize)
+			}
+		default:
+			return usage
+		}
+	}
+	if path == "" {
+		path = fmt.Sprintf("game-%v-turn-%v.png", id, turn)
+	}
+
+	board, last, err := game.BoardAt(turn)
+	if err != nil {
+		return err
+	}
+	if highlight {
+		opts.Highlight = last
+	}
+
+	write := board.WritePNG
+	switch filepath.Ext(path) {
+	case ".png":
+	case ".svg":
+		write = board.WriteSVG
+	default:
+		return fmt.Errorf("unknown image format %q, use .svg or .png", filepath.Ext(path))
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	err = write(file, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Board of game %v after turn %v written to %s\n", id, turn, path)
+	return nil
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+// Error codes of the json mode for requests that never reach the game
+const (
+	codeBadRequest    = "bad_request"
+	codeUnknownAction = "unknown_action"
+	codeNoGame        = "game_not_found"
+	codeEngine        = "engine_failed"
+)
+
+// Actions available before a game has been created or loaded
+var menuActions = []string{"new", "load", "load-position", "quit"}
+
+// Actions available at every move prompt besides the moves themselves
+var promptActions = []string{"moves", "unseen", "state", "quit"}
+
+// jsonRequest is a single line read in json mode
+// @Input the tiles of a `place` or `swap`, in any form accepted at the move prompt, the position of a `load-position`
+// @Rules name of preset rules, the casual rules when empty
+// @Layout name of a built in layout or a layout file, the board of the rules when empty
+// @Tiles name of a built in tile set, the tiles of the rules when empty
+// @Count number of plays listed by `moves`
+// @SharedRack partners of a team play from one rack, teams are given by the players' Team
+type jsonRequest struct {
+	Action     string                   `json:"action"`
+	Input      string                   `json:"input"`
+	Game       int                      `json:"game"`
+	Players    []scrabble.PlayerRequest `json:"players"`
+	Seed       int64                    `json:"seed"`
+	Rules      string                   `json:"rules"`
+	Layout     string                   `json:"layout"`
+	Tiles      string                   `json:"tiles"`
+	Count      int                      `json:"count"`
+	SharedRack bool                     `json:"shared_rack"`
+}
+
+// jsonMessage is a single line written in json mode, the type says which fields are set
+// prompt: waiting for a request, lists the actions allowed
+// turn: a move was played, by a person or a computer player
+// moves, unseen: replies to those requests
+// error: the request failed, the same prompt follows
+// game_over: the final state, the winner or in a team game the winning team, neither on a tie, and the revealed bag
+type jsonMessage struct {
+	Type        string                `json:"type"`
+	Actions     []string              `json:"actions,omitempty"`
+	State       *scrabble.GameState   `json:"state,omitempty"`
+	Commitment  string                `json:"commitment,omitempty"`
+	Player      string                `json:"player,omitempty"`
+	Input       string                `json:"input,omitempty"`
+	Result      *scrabble.Result      `json:"result,omitempty"`
+	Moves       []scrabble.PlayReport `json:"moves,omitempty"`
+	Unseen      *scrabble.UnseenTiles `json:"unseen,omitempty"`
+	Winner      string                `json:"winner,omitempty"`
+	WinningTeam string                `json:"winning_team,omitempty"`
+	Tie         bool                  `json:"tie,omitempty"`
+	Seed        int64                 `json:"seed,omitempty"`
+	Salt        string                `json:"salt,omitempty"`
+	Code        string                `json:"code,omitempty"`
+	Error       string                `json:"error,omitempty"`
+}
+
+// jsonSession drives games over stdin and stdout, one json object per line
+type jsonSession struct {
+	scanner *bufio.Scanner
+	out     *json.Encoder
+	gameDB  *scrabble.GameDB
+}
+
+// runJSONMode replaces the interactive prompts with json lines for programs driving the cli
+// `scrabble --json` ends when stdin is closed or a `quit` request is read
+func runJSONMode(in io.Reader, out io.Writer, gameDB *scrabble.GameDB) {
+	s := jsonSession{
+		scanner: bufio.NewScanner(in),
+		out:     json.NewEncoder(out),
+		gameDB:  gameDB,
+	}
+
+	var game *scrabble.Game
+	for game == nil {
+		s.send(jsonMessage{Type: "prompt", Actions: menuActions})
+		req, ok := s.read()
+		if !ok {
+			return
+		}
+
+		switch req.Action {
+		case "new":
+			err := validatePlayers(req.Players)
+			if err != nil {
+				s.fail(codeBadRequest, err)
+				continue
+			}
+			rules, err := scrabble.FindRuleSet(req.Rules)
+			if err != nil {
+				s.fail(codeBadRequest, err)
+				continue
+			}
+			opts := scrabble.GameOptions{Seed: req.Seed, Rules: &rules, SharedRack: req.SharedRack}
+			if req.Layout != "" {
+				layout, err := scrabble.FindLayout(req.Layout)
+				if err != nil {
+					s.fail(codeBadRequest, err)
+					continue
+				}
+				opts.Layout = &layout
+			}
+			tiles := req.Tiles
+			if tiles == "" {
+				tiles = rules.TileSet
+			}
+			ts, err := scrabble.FindTileSet(tiles)
+			if err != nil {
+				s.fail(codeBadRequest, err)
+				continue
+			}
+			// the word list is loaded here so a missing file is reported instead of ending the session
+			dict, err := scrabble.LoadTileSetDictionary(ts.Dictionary, ts)
+			if err != nil {
+				s.fail(codeBadRequest, err)
+				continue
+			}
+			opts.TileSet, opts.Dictionary = &ts, &dict
+			game = scrabble.NewGameWithOptions(req.Players, opts, gameDB)
+		case "load":
+			loaded, err := gameDB.GetGameByID(req.Game)
+			if err != nil {
+				s.fail(codeNoGame, err)
+				continue
+			}
+			err = checkEngines(loaded)
+			if err != nil {
+				s.fail(codeEngine, err)
+				continue
+			}
+			game = loaded
+		case "load-position":
+			err := validatePlayers(req.Players)
+			if err != nil {
+				s.fail(codeBadRequest, err)
+				continue
+			}
+			cgp, err := scrabble.ParseCGP(req.Input)
+			if err != nil {
+				s.fail(codeBadRequest, err)
+				continue
+			}
+			loaded, err := cgp.NewGame(req.Players, gameDB)
+			if err != nil {
+				s.fail(codeBadRequest, err)
+				continue
+			}
+			game = loaded
+		case "quit":
+			return
+		default:
+			s.fail(codeUnknownAction, fmt.Errorf("unknown action %q", req.Action))
+		}
+	}
+
+	s.play(game)
+}
+
+// play runs the game until it is over, computer players move without waiting for a request
+func (s jsonSession) play(game *scrabble.Game) {
+	seats, err := startComputerPlayers(game)
+	if err != nil {
+		s.fail(codeEngine, err)
+		return
+	}
+	defer scrabble.CloseComputerPlayers(seats)
+
+	commitment := game.Commitment()
+	for !game.IsOver() {
+		current := game.CurrentPlayer()
+		if seat, ok := seats[current.Name]; ok {
+			input, result, err := game.PlayComputerTurn(seat, s.gameDB)
+			if err != nil {
+				s.fail(scrabble.ErrorCode(err), err)
+				return
+			}
+			s.send(jsonMessage{Type: "turn", Player: current.Name, Input: input, Result: &result})
+			continue
+		}
+
+		state := game.State()
+		s.send(jsonMessage{
+			Type:       "prompt",
+			Actions:    append(state.Actions, promptActions...),
+			State:      &state,
+			Commitment: commitment,
+		})
+		commitment = ""
+		req, ok := s.read()
+		if !ok {
+			return
+		}
+
+		switch req.Action {
+		case "place", "swap", "pass":
+			input := strings.TrimSpace(req.Action + " " + req.Input)
+			if req.Action == "pass" {
+				input = "swap"
+			}
+			result, err := game.ApplyTurn(input, s.gameDB)
+			if err != nil {
+				s.fail(scrabble.ErrorCode(err), err)
+				continue
+			}
+			s.send(jsonMessage{Type: "turn", Player: current.Name, Input: input, Result: &result})
+		case "moves":
+			count := req.Count
+			if count <= 0 {
+				count = 10
+			}
+			moves := []scrabble.PlayReport{}
+			for i, m := range game.Moves(scrabble.DefaultLeaves) {
+				if i == count {
+					break
+				}
+				moves = append(moves, m.Report())
+			}
+			s.send(jsonMessage{Type: "moves", Moves: moves})
+		case "unseen":
+			unseen := game.Unseen(current)
+			s.send(jsonMessage{Type: "unseen", Unseen: &unseen})
+		case "state":
+			// the next prompt carries the state
+		case "quit":
+			return
+		default:
+			s.fail(codeUnknownAction, fmt.Errorf("unknown action %q", req.Action))
+		}
+	}
+
+	winner := game.End()
+	err = s.gameDB.UpsertGame(game)
+	if err != nil {
+		s.fail(scrabble.ErrorCode(err), err)
+	}
+	state := game.State()
+	seed, salt, _ := game.Reveal()
+	s.send(jsonMessage{Type: "game_over", State: &state, Winner: winner.Name, WinningTeam: game.WinningTeam().Name, Tie: game.IsTie(), Seed: seed, Salt: salt})
+}
+
+// validatePlayers checks the players of a `new` request
+func validatePlayers(players []scrabble.PlayerRequest) error {
+	if len(players) < 1 || len(players) > 4 {
+		return fmt.Errorf("a game needs 1 to 4 players")
+	}
+	for _, p := range players {
+		if _, ok := scrabble.BotLevels[string(p.Bot)]; p.Bot != "" && !ok {
+			return fmt.Errorf("unknown bot level %q", p.Bot)
+		}
+		if err := checkEngine(p.Engine); err != nil {
+			return err
+		}
+	}
+	return scrabble.ValidateTeams(players)
+}
+
+// read waits for the next request, a line that is not json is reported and skipped
+// returns false once stdin is closed
+func (s jsonSession) read() (jsonRequest, bool) {
+	for s.scanner.Scan() {
+		line := strings.TrimSpace(s.scanner.Text())
+		if line == "" {
+			continue
+		}
+		var req jsonRequest
+		err := json.Unmarshal([]byte(line), &req)
+		if err != nil {
+			s.fail(codeBadRequest, err)
+			continue
+		}
+		return req, true
+	}
+	return jsonRequest{}, false
+}
+
+func (s jsonSession) send(msg jsonMessage) {
+	err := s.out.Encode(msg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+func (s jsonSession) fail(code string, err error) {
+	s.send(jsonMessage{Type: "error", Code: code, Error: err.Error()})
+}
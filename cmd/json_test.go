@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+// newJSONTestDB opens an empty database in a temporary directory
+func newJSONTestDB(t *testing.T) *scrabble.GameDB {
+	t.Helper()
+	database, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "game.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	gameDB := scrabble.NewDB(database)
+	if err := gameDB.InitDB(); err != nil {
+		t.Fatal(err)
+	}
+	return gameDB
+}
+
+// jsonReply is a json line as a program driving the cli reads it back
+type jsonReply struct {
+	Type       string                `json:"type"`
+	Actions    []string              `json:"actions"`
+	State      json.RawMessage       `json:"state"`
+	Commitment string                `json:"commitment"`
+	Player     string                `json:"player"`
+	Result     *struct{ Score int }  `json:"result"`
+	Moves      []scrabble.PlayReport `json:"moves"`
+	Unseen     *scrabble.UnseenTiles `json:"unseen"`
+	Winner     string                `json:"winner"`
+	Tie        bool                  `json:"tie"`
+	Seed       int64                 `json:"seed"`
+	Salt       string                `json:"salt"`
+	Code       string                `json:"code"`
+}
+
+// readMessages decodes every json line written by a session
+func readMessages(t *testing.T, out *bytes.Buffer) []jsonReply {
+	t.Helper()
+	var messages []jsonReply
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		var msg jsonReply
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			t.Fatalf("%s is not a json line: %v", scanner.Text(), err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+// replies lists the type of every message, with the code of errors
+func replies(messages []jsonReply) []string {
+	var types []string
+	for _, msg := range messages {
+		if msg.Type == "error" {
+			types = append(types, "error "+msg.Code)
+			continue
+		}
+		types = append(types, msg.Type)
+	}
+	return types
+}
+
+func TestJSONModeMenu(t *testing.T) {
+	requests := strings.Join([]string{
+		`not json`,
+		`{"action":"dance"}`,
+		`{"action":"new","players":[]}`,
+		`{"action":"new","players":[{"name":"alice","engine":"./engine"}]}`,
+		`{"action":"new","players":[{"name":"alice"}],"rules":"chess"}`,
+		`{"action":"load","game":99}`,
+		`{"action":"quit"}`,
+		`{"action":"new","players":[{"name":"alice"}]}`,
+	}, "\n")
+	var out bytes.Buffer
+	runJSONMode(strings.NewReader(requests), &out, newJSONTestDB(t))
+
+	messages := readMessages(t, &out)
+	want := []string{
+		"prompt", "error bad_request", "error unknown_action",
+		"prompt", "error bad_request",
+		"prompt", "error bad_request",
+		"prompt", "error bad_request",
+		"prompt", "error game_not_found",
+		"prompt",
+	}
+	if got := replies(messages); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(messages[0].Actions, menuActions) {
+		t.Errorf("menu actions %v, want %v", messages[0].Actions, menuActions)
+	}
+}
+
+func TestJSONModePlay(t *testing.T) {
+	var bag []scrabble.Tile
+	for _, letter := range "CATSDOGXQ" {
+		bag = append(bag, scrabble.EnglishTiles.Tile(string(letter)))
+	}
+	dict := scrabble.NewDictionary([]string{"CAT", "CATS", "DOGS"})
+	gameDB := newJSONTestDB(t)
+	game := scrabble.NewGameWithOptions([]scrabble.PlayerRequest{{Name: "alice"}, {Name: "bob"}},
+		scrabble.GameOptions{Seed: 1, Bag: bag, Dictionary: &dict, FixedOrder: true}, gameDB)
+
+	// alice holds CATSDOG and bob XQ, alice goes out with CATS then DOGS down through the S
+	requests := []string{
+		`{"action":"moves","count":2}`,
+		`{"action":"unseen"}`,
+		`{"action":"place","input":"c(h,1) a(h,2) t(h,3)"}`,
+		`{"action":"swap","input":"c"}`,
+		`{"action":"place","input":"c(h,8) a(h,9) t(h,10) s(h,11)"}`,
+		`{"action":"pass"}`,
+		`{"action":"place","input":"d(e,11) o(f,11) g(g,11)"}`,
+	}
+	var out bytes.Buffer
+	s := jsonSession{
+		scanner: bufio.NewScanner(strings.NewReader(strings.Join(requests, "\n"))),
+		out:     json.NewEncoder(&out),
+		gameDB:  gameDB,
+	}
+	s.play(game)
+
+	messages := readMessages(t, &out)
+	want := []string{
+		"prompt", "moves", "prompt", "unseen", "prompt", "error invalid_start", "prompt", "error not_enough_tiles", "prompt", "turn",
+		"prompt", "turn", "prompt", "turn", "game_over",
+	}
+	if got := replies(messages); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	first := messages[0]
+	if first.State == nil || first.Commitment == "" || messages[2].Commitment != "" {
+		t.Error("the first prompt should carry the state and the commitment of the bag, only once")
+	}
+	if len(messages[1].Moves) != 2 || messages[1].Moves[0].Score != 12 {
+		t.Errorf("moves %v, want the two best plays of CATSDOG", messages[1].Moves)
+	}
+	if messages[3].Unseen == nil || messages[3].Unseen.Total != 93 {
+		t.Errorf("unseen %+v, want the 93 tiles alice has not seen", messages[3].Unseen)
+	}
+	if played := messages[9]; played.Player != "alice" || played.Result == nil || played.Result.Score != 12 {
+		t.Errorf("turn %+v, want alice to score 12", played)
+	}
+
+	over := messages[len(messages)-1]
+	if over.Winner != "alice" || over.Tie || over.Seed != 1 || over.Salt == "" || over.State == nil {
+		t.Errorf("game over %+v, want alice to win and the bag revealed", over)
+	}
+}
@@ -11,9 +11,13 @@ import (
 	"bufio"
 	"database/sql"
 	"fmt"
+	"io"
+	"log"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	scrabble "github.com/calebice/scrabble/pkg"
 )
@@ -34,39 +38,100 @@ func main() {
 		panic(err)
 	}
 
+	args := allowEngines(os.Args[1:])
+
+	// programs drive the game with json lines instead of prompts: `scrabble --json`
+	if len(args) > 0 && args[0] == "--json" {
+		runJSONMode(os.Stdin, os.Stdout, gameDB)
+		return
+	}
+
+	// standalone commands can be run directly: `scrabble find anagram AEINST?`
+	if len(args) > 0 {
+		cmd, ok := commands[args[0]]
+		if !ok {
+			fmt.Printf("Unknown command %q\n", args[0])
+			os.Exit(1)
+		}
+		err = cmd(gameDB, args[1:])
+		if err != nil {
+			fmt.Println(err)
+			code := exitFailure
+			if e, ok := err.(exitError); ok {
+				code = e.code
+			}
+			os.Exit(code)
+		}
+		return
+	}
+
 	fmt.Println(listOptions())
 
 	for game == nil {
-		action := getAction(reader)
+		action, args, err := getAction(reader)
+		if err != nil {
+			// input ended before a game was chosen
+			fmt.Println()
+			return
+		}
 
 		switch action {
 		case "new":
-			game = instantiateNewGame(reader, gameDB)
+			game, err = instantiateNewGame(reader, gameDB)
 		case "load":
 			game, err = loadGameInput(reader, gameDB)
+		case "verify":
+			err = verifyGameInput(reader, gameDB)
+		case "load-position":
+			game, err = loadPosition(reader, gameDB, args)
 		default:
-			panic(fmt.Sprintf("Requested action not implemented: %q", action))
+			cmd, ok := commands[action]
+			if !ok {
+				panic(fmt.Sprintf("Requested action not implemented: %q", action))
+			}
+			err = cmd(gameDB, args)
+		}
+		if err == io.EOF {
+			fmt.Println()
+			return
 		}
 		if err != nil {
-			fmt.Printf("Could not perform requested action: %v", err)
+			fmt.Printf("Could not perform requested action: %v\n", err)
 		}
 	}
 
 	runControlLoop(reader, game, gameDB)
 }
 
-func getAction(reader *bufio.Reader) string {
-	fmt.Println(listOptions())
-
-	fmt.Print("Please enter requested action: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSuffix(input, "\n")
+// readLine reads a line of input without its surrounding spaces
+// io.EOF is only returned once the input has ended without any text left to read
+func readLine(reader *bufio.Reader) (string, error) {
+	input, err := reader.ReadString('\n')
 	input = strings.TrimSpace(input)
-	if _, ok := optionsMap[input]; ok {
-		return input
+	if err == io.EOF && input != "" {
+		err = nil
+	}
+	return input, err
+}
+
+// getAction asks for an action until a known one is given, and returns any arguments following it
+func getAction(reader *bufio.Reader) (string, []string, error) {
+	for {
+		fmt.Println(listOptions())
+
+		fmt.Print("Please enter requested action: ")
+		input, err := readLine(reader)
+		if err != nil {
+			return "", nil, err
+		}
+		tokens := strings.Fields(input)
+		if len(tokens) > 0 {
+			if _, ok := optionsMap[tokens[0]]; ok {
+				return tokens[0], tokens[1:], nil
+			}
+		}
+		fmt.Println("Invalid action requested: ", input)
 	}
-	fmt.Println("Invalid action requested: ", input)
-	return getAction(reader)
 }
 
 func listOptions() string {
@@ -77,29 +142,38 @@ func listOptions() string {
 	return str
 }
 
-func instantiateNewGame(reader *bufio.Reader, gameDB *scrabble.GameDB) *scrabble.Game {
-	fmt.Print("Please enter number of players [1-4]: ")
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSuffix(input, "\n")
-
-	playerCount, err := strconv.Atoi(input)
-	if err != nil {
-		fmt.Println("Invalid input for players")
-		instantiateNewGame(reader, gameDB)
-	}
+// readPlayerCount asks for the number of players until one from 1 to 4 is given
+func readPlayerCount(reader *bufio.Reader) (int, error) {
+	for {
+		fmt.Print("Please enter number of players [1-4]: ")
+		input, err := readLine(reader)
+		if err != nil {
+			return 0, err
+		}
 
-	if playerCount < 1 || playerCount > 4 {
-		fmt.Println("Invalid number of players")
-		instantiateNewGame(reader, gameDB)
+		playerCount, err := strconv.Atoi(input)
+		switch {
+		case err != nil:
+			fmt.Println("Invalid input for players")
+		case playerCount < 1 || playerCount > 4:
+			fmt.Println("Invalid number of players")
+		default:
+			return playerCount, nil
+		}
 	}
+}
 
+// readPlayers asks for the name and kind of every player, and their team when playing in teams
+func readPlayers(reader *bufio.Reader, playerCount int, teams bool) ([]scrabble.PlayerRequest, error) {
 	var players []scrabble.PlayerRequest
 	for i := 0; i < playerCount; i++ {
 		var playerReq scrabble.PlayerRequest
 
 		fmt.Printf("Please enter Player %v's name: ", i+1)
-		name, _ := reader.ReadString('\n')
-		name = strings.TrimSuffix(name, "\n")
+		name, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
 		playerReq.Name = name
 
 		fmt.Printf("Use plaintext? (y/n): ")
@@ -111,79 +185,498 @@ func instantiateNewGame(reader *bufio.Reader, gameDB *scrabble.GameDB) *scrabble
 		default:
 			playerReq.UsePlainText = false
 		}
+
+		fmt.Printf("Computer player? (blank for human, easy/medium/hard/expert, or engine COMMAND): ")
+		input, _ = reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if level, ok := scrabble.BotLevels[input]; ok {
+			playerReq.Bot = level
+		}
+		if strings.HasPrefix(input, "engine ") {
+			playerReq.Engine = strings.TrimSpace(strings.TrimPrefix(input, "engine "))
+			allowedEngines[playerReq.Engine] = true
+		}
+
+		if teams {
+			fmt.Printf("Team name: ")
+			input, _ = reader.ReadString('\n')
+			playerReq.Team = strings.TrimSpace(input)
+		}
 		players = append(players, playerReq)
 	}
+	return players, nil
+}
+
+func instantiateNewGame(reader *bufio.Reader, gameDB *scrabble.GameDB) (*scrabble.Game, error) {
+	playerCount, err := readPlayerCount(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts scrabble.GameOptions
+	var teams bool
+	var input string
+	if playerCount == 4 {
+		fmt.Print("Play in teams of two? (blank for no, separate or shared racks): ")
+		input, _ = reader.ReadString('\n')
+		switch strings.TrimSpace(input) {
+		case "separate":
+			teams = true
+		case "shared":
+			teams, opts.SharedRack = true, true
+		}
+	}
+
+	// players are asked again until the teams are two players each
+	players, err := readPlayers(reader, playerCount, teams)
+	for err == nil {
+		err = scrabble.ValidateTeams(players)
+		if err == nil {
+			break
+		}
+		fmt.Println(err)
+		players, err = readPlayers(reader, playerCount, teams)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	for _, p := range players {
 		fmt.Printf("%+v\n", p)
 	}
 
-	return scrabble.NewGame(players, gameDB)
+	fmt.Print("Please enter a seed for the tile bag (blank for random): ")
+	input, _ = reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input != "" {
+		// out of range numbers are rejected as well, ParseInt would clamp them to the largest seed
+		seed, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			fmt.Println("Invalid seed, using a random seed")
+		} else {
+			opts.Seed = seed
+		}
+	}
+
+	fmt.Printf("Please enter the rules (blank for casual, or one of %v): ", scrabble.RuleSetNames())
+	input, _ = reader.ReadString('\n')
+	rules, err := scrabble.FindRuleSet(strings.TrimSpace(input))
+	if err != nil {
+		fmt.Printf("%v, using casual rules\n", err)
+		rules = scrabble.CasualRules
+	}
+	opts.Rules = &rules
+
+	fmt.Printf("Please enter a board layout (blank for %s, standard, super, or a layout file): ", rules.Layout)
+	input, _ = reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input != "" {
+		layout, err := scrabble.FindLayout(input)
+		if err != nil {
+			fmt.Printf("Could not load layout, using the %s board: %v\n", rules.Layout, err)
+		} else {
+			opts.Layout = &layout
+		}
+	}
+
+	fmt.Printf("Please enter a tile set (blank for %s, or one of %v): ", rules.TileSet, scrabble.TileSetNames())
+	input, _ = reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		input = rules.TileSet
+	}
+	ts, err := scrabble.FindTileSet(input)
+	if err != nil {
+		fmt.Printf("%v, using %s tiles\n", err, rules.TileSet)
+	} else if ts.Name != rules.TileSet {
+		dict, err := scrabble.LoadTileSetDictionary(ts.Dictionary, ts)
+		if err != nil {
+			fmt.Printf("Could not load the %s dictionary, using %s tiles: %v\n", ts.Name, rules.TileSet, err)
+		} else {
+			opts.TileSet, opts.Dictionary = &ts, &dict
+		}
+	}
+
+	return scrabble.NewGameWithOptions(players, opts, gameDB), nil
 }
 
 func runControlLoop(reader *bufio.Reader, game *scrabble.Game, gameDB *scrabble.GameDB) {
-	fmt.Printf("Current game id: %v\n\n", game.GetID())
+	leaves := scrabble.DefaultLeaves
+	fmt.Printf("Current game id: %v\nBag commitment: %s\n\n", game.GetID(), game.Commitment())
+
+	seats, err := startComputerPlayers(game)
+	if err != nil {
+		fmt.Printf("Could not start computer players: %v\n", err)
+		return
+	}
+	defer scrabble.CloseComputerPlayers(seats)
+
+	// the full screen interface collects all output in its message panel
+	var out io.Writer = os.Stdout
+	var screen *terminalUI
+	if useFullScreen(reader) {
+		screen, err = startTerminalUI()
+		if err != nil {
+			fmt.Printf("Could not start the full screen interface, using the line prompt: %v\n", err)
+		} else {
+			defer screen.Close()
+			out = screen
+		}
+	}
 
 	for {
 		current := game.CurrentPlayer()
-		if current.UsePlainText {
-			fmt.Println("DISCLAIMER ------ THE FOLLOWING IS FOR A TEXT BASED GAME OF SCRABBLE -------")
+		if screen == nil {
+			if current.UsePlainText {
+				fmt.Fprintln(out, "DISCLAIMER ------ THE FOLLOWING IS FOR A TEXT BASED GAME OF SCRABBLE -------")
+			}
+			fmt.Fprintf(out, "%s: %v\n%s\n", current.Name,
+				current.Score(), current.Tiles())
+			fmt.Fprintln(out, game.GetBoard().FormatPrint(current.UsePlainText))
 		}
-		fmt.Printf("%s: %v\n%s\n", current.Name,
-			current.Score(), current.Tiles())
-		fmt.Println(game.GetBoard().FormatPrint(current.UsePlainText))
-
-		fmt.Print("Please enter move: ")
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSuffix(input, "\n")
 
-		result, err := game.ApplyTurn(input, gameDB)
-		if err != nil {
-			fmt.Println(err)
+		if seat, ok := seats[current.Name]; ok {
+			if screen != nil {
+				screen.Render(game, fmt.Sprintf("%s is thinking...", current.Name))
+			}
+			input, result, err := game.PlayComputerTurn(seat, gameDB)
+			if err != nil {
+				fmt.Fprintln(out, err)
+				return
+			}
+			fmt.Fprintf(out, "%s (computer) plays: %s\n%s: %s\n", current.Name, input, current.Name, result.String())
+			if printTurnSummary(out, game, gameDB) {
+				if screen != nil {
+					screen.Wait(game)
+				}
+				return
+			}
+			continue
 		}
 
-		fmt.Printf("%s: %s", current.Name, result.String())
+		var input string
+		if screen != nil {
+			input = screen.ReadInput(game)
+		} else {
+			fmt.Print("Please enter move: ")
+			input, err = readLine(reader)
+			if err != nil {
+				// the game is saved after every turn and can be loaded again
+				fmt.Fprintln(out)
+				return
+			}
+		}
 
-		fmt.Println()
+		if input == "tiles" {
+			fmt.Fprintln(out, game.Unseen(current))
+			fmt.Fprintln(out)
+			continue
+		}
+		if input == "position" {
+			fmt.Fprintf(out, "%s\n\n", game.CGP().Seen())
+			continue
+		}
+		if strings.HasPrefix(input, "moves") {
+			printMoves(out, game.Position(), leaves, strings.Fields(input)[1:])
+			continue
+		}
+		if strings.HasPrefix(input, "find ") {
+			err := findWords(out, game.Dictionary, strings.Fields(input)[1:])
+			if err != nil {
+				fmt.Fprintln(out, err)
+			}
+			continue
+		}
+		if strings.HasPrefix(input, "solve") {
+			printEndgame(out, game.Position(), strings.Fields(input)[1:])
+			continue
+		}
+		if strings.HasPrefix(input, "sim") {
+			printSimulation(out, game.Position(), strings.Fields(input)[1:])
+			continue
+		}
+		if strings.HasPrefix(input, "leaves ") {
+			loaded, err := scrabble.LoadLeaveTable(strings.TrimSpace(strings.TrimPrefix(input, "leaves ")))
+			if err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+			leaves = loaded
+			fmt.Fprintf(out, "Loaded %v leave values\n\n", len(leaves.Values))
+			continue
+		}
 
-		fmt.Println("Tiles Remaining: ", len(game.Tiles.GetTiles()))
-		fmt.Println("Scores")
-		fmt.Println("--------------------")
-		for _, p := range game.GetPlayers() {
-			fmt.Printf("%s: %v\n", p.Name, p.Score())
+		result, err := game.ApplyTurn(input, gameDB)
+		if err != nil {
+			fmt.Fprintln(out, err)
 		}
-		fmt.Println("--------------------")
 
-		if len(game.CurrentPlayer().Tiles()) == 0 {
-			winner := game.End()
-			fmt.Printf("Winning player: %s with %v points", winner.Name, winner.Score())
-			fmt.Println("Stats")
-			fmt.Println("--------------------")
-			for _, p := range game.GetPlayers() {
-				fmt.Printf("%s: %v points, highest scoring word: %s %v points", p.Name, p.Score(), p.HighestWord(), p.HighestScore())
+		fmt.Fprintf(out, "%s: %s", current.Name, result.String())
+
+		fmt.Fprintln(out)
+		if printTurnSummary(out, game, gameDB) {
+			if screen != nil {
+				screen.Wait(game)
 			}
 			return
 		}
 	}
 }
 
+// startComputerPlayers starts the bots and engines of the game, engines only when they are allowed
+// engine conversations are written to engine.log
+func startComputerPlayers(game *scrabble.Game) (map[string]scrabble.Strategy, error) {
+	if err := checkEngines(game); err != nil {
+		return nil, err
+	}
+	var logger *log.Logger
+	for _, p := range game.GetPlayers() {
+		if p.Engine == "" {
+			continue
+		}
+		logFile, err := os.OpenFile(engineLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+		logger = log.New(logFile, "", log.LstdFlags)
+		break
+	}
+	return game.ComputerPlayers(logger)
+}
+
+// printTurnSummary prints the scores after a turn, ending the game once a player has gone out
+// returns true when the game is over
+func printTurnSummary(out io.Writer, game *scrabble.Game, gameDB *scrabble.GameDB) bool {
+	fmt.Fprintln(out, "Tiles Remaining: ", len(game.Tiles.GetTiles()))
+	fmt.Fprintln(out, "Scores")
+	fmt.Fprintln(out, "--------------------")
+	for _, p := range game.GetPlayers() {
+		fmt.Fprintf(out, "%s: %v\n", p.Name, p.Score())
+	}
+	printTeams(out, game)
+	fmt.Fprintln(out, "--------------------")
+
+	if !game.IsOver() {
+		return false
+	}
+	winner := game.End()
+	err := gameDB.UpsertGame(game)
+	if err != nil {
+		fmt.Fprintln(out, err)
+	}
+	team := game.WinningTeam()
+	switch {
+	case game.IsTie():
+		fmt.Fprintln(out, "The game is a tie")
+	case team.Name != "":
+		fmt.Fprintf(out, "Winning team: %s with %v points\n", team.Name, team.Score)
+	case winner.Name != "":
+		fmt.Fprintf(out, "Winning player: %s with %v points\n", winner.Name, winner.Score())
+	default:
+		fmt.Fprintln(out, "Nobody won, no score is above zero")
+	}
+	fmt.Fprintln(out, "Stats")
+	fmt.Fprintln(out, "--------------------")
+	for _, t := range game.Teams() {
+		fmt.Fprintf(out, "%s: %v points, highest scoring word: %s %v points\n", t.Name, t.Score, t.HighestWord, t.HighestScore)
+	}
+	for _, p := range game.GetPlayers() {
+		fmt.Fprintf(out, "%s: %v points, highest scoring word: %s %v points", p.Name, p.Score(), p.HighestWord(), p.HighestScore())
+	}
+	seed, salt, _ := game.Reveal()
+	fmt.Fprintf(out, "\nBag revealed: seed %v salt %s (commitment %s)\n", seed, salt, game.Commitment())
+	return true
+}
+
+// printTeams prints the shared score of every team, nothing when the game is not played in teams
+func printTeams(out io.Writer, game *scrabble.Game) {
+	for _, t := range game.Teams() {
+		fmt.Fprintf(out, "Team %s\n", t)
+	}
+}
+
 var optionsMap = map[string]string{
-	"list":   "list all current games",
-	"new":    "create a new game",
-	"load":   "load a game using game id",
-	"delete": "delete a game using id",
-	"stats":  "display stats for a given player",
+	"list":          "list all current games",
+	"new":           "create a new game",
+	"load":          "load a game using game id",
+	"delete":        "delete a game using id",
+	"stats":         "display stats for a given player",
+	"verify":        "verify a finished game was played from its committed bag",
+	"find":          "search the dictionary: find anagram|build|pattern|contains|starts LETTERS [-min N] [-max N] [-sort alpha|length|score] [-tiles NAME]",
+	"analyze":       "review every turn of a game against the engine: analyze GAME_ID [-json] [-plays N]",
+	"export-image":  "draw the board of a game as an svg or png: export-image GAME_ID [TURN] [-o FILE] [-size PIXELS] [-no-highlight]",
+	"scenario":      "play the moves of a scenario file and print the final state: scenario FILE",
+	"selfplay":      "play computer players against each other: selfplay [-games N] [-p1 LEVEL] [-p2 LEVEL] [-seed S] [-format text|csv|json]",
+	"duplicate":     "play duplicate, everyone plays the same rack each round: duplicate [-seed S] [-rules NAME] PLAYER[:LEVEL]...",
+	"puzzle":        "find the top play of the daily puzzle: puzzle [-user NAME] [-id N] | puzzle generate [GAME_ID...] | puzzle stats NAME",
+	"study":         "drill anagrams with spaced repetition: study [-user NAME] [-length N] [-ranks FROM-TO] [-contains LETTERS] | study stats NAME",
+	"setup":         "analyze a position set up by hand: setup -rack LETTERS [-rows FILE] [-play NOTATION]... [-unseen LETTERS] [-scores ME,THEM]",
+	"load-position": "start a game from a position in one line: load-position \"ROWS RACKS SCORES ZEROS [lex NAME;] [rules NAME;]\"",
+}
+
+// engineLogPath collects the conversations with external engines
+const engineLogPath = "engine.log"
+
+// allowedEngines are the engine commands this run may start, those typed at the prompts
+// and those given with `--allow-engine COMMAND`, never commands read from a json request or a saved game
+var allowedEngines = map[string]bool{}
+
+// allowEngines takes the `--allow-engine COMMAND` options out of the arguments
+func allowEngines(args []string) []string {
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--allow-engine" && i+1 < len(args) {
+			i++
+			allowedEngines[strings.TrimSpace(args[i])] = true
+			continue
+		}
+		rest = append(rest, args[i])
+	}
+	return rest
+}
+
+// checkEngines checks that every engine of the game may be started
+func checkEngines(game *scrabble.Game) error {
+	for _, p := range game.GetPlayers() {
+		if err := checkEngine(p.Engine); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEngine checks that the engine command of a seat may be started, seats without an engine always pass
+func checkEngine(command string) error {
+	if command != "" && !allowedEngines[command] {
+		return scrabble.ErrEngineNotAllowed{Command: command}
+	}
+	return nil
+}
+
+// command represents an action that runs without an active game
+type command func(gameDB *scrabble.GameDB, args []string) error
+
+// commands that can be run from the menu or directly from the command line
+var commands = map[string]command{
+	"find":          runFind,
+	"analyze":       runAnalyze,
+	"selfplay":      runSelfPlay,
+	"scenario":      runScenario,
+	"export-image":  runExportImage,
+	"duplicate":     runDuplicate,
+	"puzzle":        runPuzzle,
+	"study":         runStudy,
+	"setup":         runSetup,
+	"load-position": runLoadPosition,
 }
 
 func loadGameInput(reader *bufio.Reader, gameDB *scrabble.GameDB) (*scrabble.Game, error) {
+	for {
+		fmt.Print("Please enter game ID: ")
+		input, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		i, err := strconv.Atoi(input)
+		if err == nil {
+			return gameDB.GetGameByID(i)
+		}
+		fmt.Printf("Invalid game id %q. Please enter a valid id\n", input)
+	}
+}
+
+func verifyGameInput(reader *bufio.Reader, gameDB *scrabble.GameDB) error {
 	fmt.Print("Please enter game ID: ")
 	input, _ := reader.ReadString('\n')
-	input = strings.TrimSuffix(input, "\n")
+	input = strings.TrimSpace(input)
 
 	i, err := strconv.Atoi(input)
 	if err != nil {
-		fmt.Printf("Invalid game id %q. Please enter a valid id\n", input)
-		loadGameInput(reader, gameDB)
+		return err
+	}
+	game, err := gameDB.GetGameByID(i)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Bag commitment: %s\n", game.Commitment())
+	seed, salt, err := game.Reveal()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Revealed seed: %v salt: %s\n", seed, salt)
+
+	err = scrabble.VerifyGame(game)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Game %v verified: all %v turns match the committed bag\n", game.GetID(), len(game.Turns))
+	return nil
+}
+
+// printMoves lists the best plays for the player to move
+// `moves` ranks by equity, `moves score` ranks by raw score
+func printMoves(out io.Writer, pos scrabble.Position, leaves scrabble.LeaveTable, args []string) {
+	moves := pos.Moves(leaves)
+	if len(args) > 0 && args[0] == "score" {
+		sort.Sort(scrabble.ByScore(moves))
+	}
+	for i, m := range moves {
+		if i == 10 {
+			break
+		}
+		fmt.Fprintf(out, "%2v. %s\n    %s\n", i+1, m, m.Input())
+	}
+	fmt.Fprintln(out)
+}
+
+// printSimulation simulates the best plays for the player to move
+// `sim [seconds] [plies]` defaults to 10 seconds, 2 plies
+func printSimulation(out io.Writer, pos scrabble.Position, args []string) {
+	opts := scrabble.SimOptions{Duration: 10 * time.Second}
+	if len(args) > 0 {
+		seconds, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Fprintln(out, "Invalid number of seconds")
+			return
+		}
+		opts.Duration = time.Duration(seconds) * time.Second
+	}
+	if len(args) > 1 {
+		plies, err := strconv.Atoi(args[1])
+		if err != nil {
+			fmt.Fprintln(out, "Invalid number of plies")
+			return
+		}
+		opts.Plies = plies
+	}
+
+	fmt.Fprintf(out, "Simulating for %v...\n", opts.Duration)
+	for i, r := range pos.SimulateTop(0, opts) {
+		fmt.Fprintf(out, "%2v. %s\n", i+1, r)
+	}
+	fmt.Fprintln(out)
+}
+
+// printEndgame solves the endgame for the player to move once the bag is empty
+// `solve [seconds]` defaults to 10 seconds
+func printEndgame(out io.Writer, pos scrabble.Position, args []string) {
+	var opts scrabble.EndgameOptions
+	if len(args) > 0 {
+		seconds, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Fprintln(out, "Invalid number of seconds")
+			return
+		}
+		opts.Duration = time.Duration(seconds) * time.Second
+	}
+
+	result, err := scrabble.SolveEndgame(pos, opts)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
 	}
-	return gameDB.GetGameByID(i)
+	fmt.Fprintln(out, result)
+	fmt.Fprintln(out)
 }
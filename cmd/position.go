@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+const loadPositionUsage = `usage: load-position "ROWS RACKS SCORES ZEROS [lex NAME;] [rules NAME;] [layout NAME;]"`
+
+// runLoadPosition starts a game from a position in one line notation and plays it
+// `scrabble load-position "15/15/15/15/15/15/15/7QUIXOTIc/15/15/15/15/15/15/15 AEINRS?/ 0/116 0 lex english;"`
+func runLoadPosition(gameDB *scrabble.GameDB, args []string) error {
+	reader := bufio.NewReader(os.Stdin)
+	game, err := loadPosition(reader, gameDB, args)
+	if err != nil {
+		return err
+	}
+	runControlLoop(reader, game, gameDB)
+	return nil
+}
+
+// loadPosition reads the position from the arguments, quoted or not, and asks for a player for every rack
+func loadPosition(reader *bufio.Reader, gameDB *scrabble.GameDB, args []string) (*scrabble.Game, error) {
+	text := strings.Trim(strings.Join(args, " "), `"'`)
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New(loadPositionUsage)
+	}
+	cgp, err := scrabble.ParseCGP(text)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println(cgp.Board)
+
+	var players []scrabble.PlayerRequest
+	for i, rack := range cgp.Racks {
+		letters := "drawn from the bag"
+		if len(rack) > 0 {
+			letters = fmt.Sprint(rack)
+		}
+		fmt.Printf("Player %v has %v points, rack %s\n", i+1, cgp.Scores[i], letters)
+
+		var playerReq scrabble.PlayerRequest
+		fmt.Printf("Please enter Player %v's name: ", i+1)
+		name, _ := reader.ReadString('\n')
+		playerReq.Name = strings.TrimSpace(name)
+
+		fmt.Printf("Computer player? (blank for human, easy/medium/hard/expert, or engine COMMAND): ")
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if level, ok := scrabble.BotLevels[input]; ok {
+			playerReq.Bot = level
+		}
+		if strings.HasPrefix(input, "engine ") {
+			playerReq.Engine = strings.TrimSpace(strings.TrimPrefix(input, "engine "))
+			allowedEngines[playerReq.Engine] = true
+		}
+		players = append(players, playerReq)
+	}
+	return cgp.NewGame(players, gameDB)
+}
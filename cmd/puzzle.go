@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+const puzzleUsage = "usage: puzzle [-user NAME] [-id N] | puzzle generate [GAME_ID...] [-min-score N] [-min-missed N] | puzzle stats NAME"
+
+// runPuzzle poses the puzzle of the day, or a given one, and records the answer of the user
+// `puzzle generate` makes puzzles of the finished games, `puzzle stats NAME` shows how a user is doing
+func runPuzzle(gameDB *scrabble.GameDB, args []string) error {
+	if len(args) > 0 && args[0] == "generate" {
+		return generatePuzzles(gameDB, args[1:])
+	}
+	if len(args) > 0 && args[0] == "stats" {
+		if len(args) != 2 {
+			return errors.New(puzzleUsage)
+		}
+		stats, err := gameDB.PuzzleStats(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Println(stats)
+		return nil
+	}
+
+	var name string
+	var id int64
+	for i := 0; i < len(args); i++ {
+		if i+1 == len(args) {
+			return errors.New(puzzleUsage)
+		}
+		i++
+		switch args[i-1] {
+		case "-user":
+			name = args[i]
+		case "-id":
+			var err error
+			id, err = strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return err
+			}
+		default:
+			return errors.New(puzzleUsage)
+		}
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	if name == "" {
+		fmt.Print("Please enter your name: ")
+		input, _ := reader.ReadString('\n')
+		name = strings.TrimSpace(input)
+	}
+
+	var puzzle *scrabble.Puzzle
+	var err error
+	if id == 0 {
+		puzzle, err = gameDB.DailyPuzzle(time.Now())
+	} else {
+		puzzle, err = gameDB.GetPuzzle(id)
+	}
+	if err != nil {
+		return err
+	}
+	attempted, err := gameDB.PuzzleAttempted(name, puzzle.ID)
+	if err != nil {
+		return err
+	}
+	if attempted {
+		return scrabble.ErrPuzzleAttempted
+	}
+	return solvePuzzle(reader, os.Stdout, gameDB, name, *puzzle)
+}
+
+// solvePuzzle asks for plays until one can be made from the rack, then records it and reveals the answer
+func solvePuzzle(reader *bufio.Reader, out io.Writer, gameDB *scrabble.GameDB, name string, puzzle scrabble.Puzzle) error {
+	fmt.Fprintln(out, puzzle)
+	var attempt scrabble.PuzzleAttempt
+	for {
+		fmt.Fprint(out, "Your play: ")
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		attempt, err = puzzle.Check(input)
+		if err == nil {
+			break
+		}
+		fmt.Fprintf(out, "Could not play that: %v\n", err)
+	}
+
+	err := gameDB.InsertPuzzleAttempt(name, attempt)
+	if err != nil {
+		return err
+	}
+	switch {
+	case attempt.Solved:
+		fmt.Fprintf(out, "Solved! %s scores %v points\n", attempt.Play, attempt.Score)
+	case attempt.Play == "":
+		fmt.Fprintln(out, "Not a valid play, 0 points")
+	default:
+		fmt.Fprintf(out, "%s scores %v points\n", attempt.Play, attempt.Score)
+	}
+	fmt.Fprintf(out, "Top play: %s %v points\n", puzzle.Answer.Play, puzzle.Answer.Score)
+	fmt.Fprintf(out, "%s played: %s\n", puzzle.Player, puzzle.Missed)
+
+	stats, err := gameDB.PuzzleStats(name)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, stats)
+	return nil
+}
+
+// generatePuzzles makes puzzles of the given games, or of every finished game
+// `puzzle generate [GAME_ID...] [-min-score N] [-min-missed N]`
+func generatePuzzles(gameDB *scrabble.GameDB, args []string) error {
+	var opts scrabble.PuzzleOptions
+	var ids []int
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			id, err := strconv.Atoi(args[i])
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+			continue
+		}
+		if i+1 == len(args) {
+			return errors.New(puzzleUsage)
+		}
+		i++
+		value, err := strconv.Atoi(args[i])
+		if err != nil {
+			return err
+		}
+		switch args[i-1] {
+		case "-min-score":
+			opts.MinScore = value
+		case "-min-missed":
+			opts.MinMissed = value
+		default:
+			return errors.New(puzzleUsage)
+		}
+	}
+
+	if len(ids) == 0 {
+		var err error
+		ids, err = gameDB.FinishedGameIDs()
+		if err != nil {
+			return err
+		}
+	}
+	var total int
+	for _, id := range ids {
+		game, err := gameDB.GetGameByID(id)
+		if err != nil {
+			return err
+		}
+		puzzles, err := scrabble.FindPuzzles(game, opts)
+		if err != nil {
+			// games that cannot be replayed, such as those with an ordered bag, have no puzzles
+			fmt.Printf("Game %v: %v\n", id, err)
+			continue
+		}
+		added, err := gameDB.InsertPuzzles(puzzles)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Game %v: %v new puzzles\n", id, added)
+		total += added
+	}
+	fmt.Printf("%v puzzles added\n", total)
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+// Exit codes of standalone commands
+const (
+	exitFailure        = 1
+	exitScenarioFormat = 2
+	exitIllegalMove    = 3
+)
+
+// exitError pairs an error with the exit code the process should end with
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e exitError) Error() string {
+	return e.err.Error()
+}
+
+// runScenario plays the moves of a scenario file and prints the final state
+// `scenario FILE` exits with 2 when the file cannot be parsed and 3 when a move fails
+func runScenario(gameDB *scrabble.GameDB, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: scenario FILE")
+	}
+	return playScenario(args[0], os.Stdout)
+}
+
+// playScenario loads a scenario file, plays it and writes the final state to out
+func playScenario(path string, out io.Writer) error {
+	scenario, err := scrabble.LoadScenario(path)
+	if err != nil {
+		if _, ok := err.(scrabble.ErrScenarioFormat); ok {
+			return exitError{code: exitScenarioFormat, err: err}
+		}
+		return err
+	}
+
+	game, err := scenario.Play()
+	printGameState(out, game)
+	if err != nil {
+		return exitError{code: exitIllegalMove, err: err}
+	}
+	return nil
+}
+
+// printGameState prints the board, every rack and score, and whether the game is over
+func printGameState(out io.Writer, game *scrabble.Game) {
+	if seed, _, err := game.Reveal(); err == nil {
+		fmt.Fprintf(out, "Seed: %v\n", seed)
+	}
+	fmt.Fprintln(out, game.GetBoard())
+	for _, p := range game.GetPlayers() {
+		fmt.Fprintf(out, "%s: %v %s\n", p.Name, p.Score(), p.Tiles())
+	}
+	printTeams(out, game)
+	fmt.Fprintf(out, "Tiles Remaining: %v\nTurns played: %v\n", len(game.Tiles.GetTiles()), len(game.Turns))
+	team, winner := game.WinningTeam(), game.Winner()
+	switch {
+	case !game.IsFinished():
+		fmt.Fprintf(out, "Next to play: %s\n", game.CurrentPlayer().Name)
+	case game.IsTie():
+		fmt.Fprintln(out, "Game over, a tie")
+	case team.Name != "":
+		fmt.Fprintf(out, "Game over, winning team: %s with %v points\n", team.Name, team.Score)
+	case winner.Name != "":
+		fmt.Fprintf(out, "Game over, winning player: %s with %v points\n", winner.Name, winner.Score())
+	default:
+		fmt.Fprintln(out, "Game over, nobody won")
+	}
+}
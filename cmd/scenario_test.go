@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeScenario writes a scenario for alice and bob drawing from an ordered bag, with a word list of CAT and CATS
+func writeScenario(t *testing.T, moves string) string {
+	t.Helper()
+	dir := t.TempDir()
+	dict := filepath.Join(dir, "words.txt")
+	if err := os.WriteFile(dict, []byte("CAT\nCATS\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "game.txt")
+	text := "player alice\nplayer bob\nbag CATSDOGEEIRNTS\ndictionary " + dict + "\n" + moves
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPlayScenarioExitCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		moves  string
+		code   int
+		output string
+	}{
+		{"played", "h8 CAT\n", 0, "Next to play: bob"},
+		{"format error", "h8 CAT\nsede 4\n", exitScenarioFormat, ""},
+		{"illegal move", "h8 CAT\nh1 CATS\n", exitIllegalMove, "Turns played: 1"},
+		{"word not in the dictionary", "h8 DOG\n", exitIllegalMove, "Next to play: alice"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		err := playScenario(writeScenario(t, tt.moves), &out)
+		code := 0
+		if err != nil {
+			e, ok := err.(exitError)
+			if !ok {
+				t.Errorf("%s: got %v, want an exit code", tt.name, err)
+				continue
+			}
+			code = e.code
+		}
+		if code != tt.code || !strings.Contains(out.String(), tt.output) {
+			t.Errorf("%s: exit code %v, want %v, output:\n%s", tt.name, code, tt.code, out.String())
+		}
+	}
+
+	err := playScenario(filepath.Join(t.TempDir(), "missing.txt"), &bytes.Buffer{})
+	if _, ok := err.(exitError); err == nil || ok {
+		t.Errorf("a missing file: got %v, want a plain failure", err)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+// selfPlayUsage describes the options of the selfplay command
+const selfPlayUsage = "usage: selfplay [-games N] [-p1 LEVEL] [-p2 LEVEL] [-e1 COMMAND] [-e2 COMMAND] [-seed S] [-workers N] [-format text|csv|json]"
+
+// runSelfPlay plays games between two computer players and prints their statistics
+// `selfplay [-games N] [-p1 LEVEL] [-p2 LEVEL] [-e1 COMMAND] [-e2 COMMAND] [-seed S] [-workers N] [-format text|csv|json]`
+// engine conversations are written to engine.log
+func runSelfPlay(gameDB *scrabble.GameDB, args []string) error {
+	opts := scrabble.SelfPlayOptions{Games: 100}
+	levels := []scrabble.BotLevel{scrabble.BotHard, scrabble.BotMedium}
+	engines := make([]string, 2)
+	format := "text"
+
+	if len(args)%2 != 0 {
+		return errors.New(selfPlayUsage)
+	}
+	for i := 0; i < len(args); i += 2 {
+		var err error
+		value := args[i+1]
+		switch args[i] {
+		case "-games":
+			opts.Games, err = strconv.Atoi(value)
+		case "-seed":
+			opts.Seed, err = strconv.ParseInt(value, 10, 64)
+		case "-workers":
+			opts.Workers, err = strconv.Atoi(value)
+		case "-p1", "-p2":
+			level, ok := scrabble.BotLevels[value]
+			if !ok {
+				return fmt.Errorf("unknown bot level %q", value)
+			}
+			levels[args[i][2]-'1'] = level
+		case "-e1", "-e2":
+			engines[args[i][2]-'1'] = value
+		case "-format":
+			if value != "text" && value != "csv" && value != "json" {
+				return fmt.Errorf("unknown format %q, %s", value, selfPlayUsage)
+			}
+			format = value
+		default:
+			err = fmt.Errorf("unknown option %q", args[i])
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, level := range levels {
+		player := scrabble.PlayerRequest{
+			Name: fmt.Sprintf("p%v-%s", i+1, level),
+			Bot:  level,
+		}
+		if engines[i] != "" {
+			player = scrabble.PlayerRequest{
+				Name:   fmt.Sprintf("p%v-engine", i+1),
+				Engine: engines[i],
+			}
+		}
+		opts.Players = append(opts.Players, player)
+	}
+
+	if engines[0] != "" || engines[1] != "" {
+		logFile, err := os.OpenFile(engineLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer logFile.Close()
+		opts.EngineLog = log.New(logFile, "", log.LstdFlags)
+	}
+
+	result, err := scrabble.SelfPlay(opts)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "csv":
+		return result.WriteCSV(os.Stdout)
+	case "json":
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	default:
+		fmt.Println(result)
+	}
+	return nil
+}
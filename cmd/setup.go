@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+const setupUsage = "usage: setup -rack LETTERS [-rows FILE] [-play NOTATION]... [-unseen LETTERS] [-scores ME,THEM] [-rules NAME] [-layout NAME|FILE] [-tiles NAME]"
+
+// runSetup analyzes a position set up by hand, without players, turns or a database
+// `setup -rows board.txt -rack AEINRS_ -scores 320,298` then `moves`, `sim` or `solve` at the prompt
+func runSetup(gameDB *scrabble.GameDB, args []string) error {
+	var setup scrabble.PositionSetup
+	for i := 0; i < len(args); i++ {
+		if i+1 == len(args) {
+			return errors.New(setupUsage)
+		}
+		i++
+		switch args[i-1] {
+		case "-rack":
+			setup.Rack = args[i]
+		case "-unseen":
+			setup.Unseen = args[i]
+		case "-play":
+			setup.Plays = append(setup.Plays, args[i])
+		case "-rows":
+			rows, err := readRows(args[i])
+			if err != nil {
+				return err
+			}
+			setup.Rows = rows
+		case "-scores":
+			scores := strings.SplitN(args[i], ",", 2)
+			for j, s := range scores {
+				score, err := strconv.Atoi(strings.TrimSpace(s))
+				if err != nil {
+					return err
+				}
+				setup.Scores[j] = score
+			}
+		case "-rules":
+			rules, err := scrabble.FindRuleSet(args[i])
+			if err != nil {
+				return err
+			}
+			setup.Rules = &rules
+		case "-layout":
+			layout, err := scrabble.FindLayout(args[i])
+			if err != nil {
+				return err
+			}
+			setup.Layout = &layout
+		case "-tiles":
+			ts, err := scrabble.FindTileSet(args[i])
+			if err != nil {
+				return err
+			}
+			setup.TileSet = &ts
+		default:
+			return errors.New(setupUsage)
+		}
+	}
+	if setup.Rack == "" {
+		return errors.New(setupUsage)
+	}
+
+	pos, err := setup.Position()
+	if err != nil {
+		return err
+	}
+	analyzePosition(bufio.NewReader(os.Stdin), os.Stdout, pos)
+	return nil
+}
+
+// readRows reads the rows of a board from a text grid, one row per line, blank lines and lines starting with # are skipped
+func readRows(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rows []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rows = append(rows, line)
+	}
+	return rows, scanner.Err()
+}
+
+// analyzePosition answers `moves`, `sim`, `solve` and `tiles` for the position until `quit`
+func analyzePosition(reader *bufio.Reader, out io.Writer, pos scrabble.Position) {
+	fmt.Fprintln(out, pos.Board)
+	fmt.Fprintf(out, "Rack: %s\nScores: %v to %v\nUnseen: %v tiles, %v in the bag\n",
+		pos.Rack(), pos.Scores[0], pos.Scores[1], len(pos.Unseen), pos.BagSize)
+
+	leaves := scrabble.DefaultLeaves
+	for {
+		fmt.Fprint(out, "Please enter moves [score], sim [seconds] [plies], solve [seconds], tiles or quit: ")
+		input, err := reader.ReadString('\n')
+		if err != nil && input == "" {
+			return
+		}
+		fields := strings.Fields(input)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "moves":
+			printMoves(out, pos, leaves, fields[1:])
+		case "sim":
+			printSimulation(out, pos, fields[1:])
+		case "solve":
+			printEndgame(out, pos, fields[1:])
+		case "tiles":
+			fmt.Fprintln(out, pos.UnseenCounts())
+		case "quit":
+			return
+		default:
+			fmt.Fprintf(out, "Unknown request %q\n", fields[0])
+		}
+	}
+}
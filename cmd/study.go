@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+const studyUsage = "usage: study [-user NAME] [-length N] [-ranks FROM-TO] [-contains LETTERS] [-count N] [-tiles NAME] | study stats NAME [-tiles NAME]"
+
+// defaultStudyCount is the number of questions asked in a session
+const defaultStudyCount = 20
+
+// runStudy quizzes a user on the anagrams of a set of words, questions come back on a spaced repetition schedule
+// `study -user alice -length 7 -ranks 1-500` drills the 500 most probable sevens, `-contains JQXZ` words with those letters
+func runStudy(gameDB *scrabble.GameDB, args []string) error {
+	var opts scrabble.StudyOptions
+	var name string
+	var stats bool
+	count := defaultStudyCount
+	ts := scrabble.EnglishTiles
+	if len(args) > 1 && args[0] == "stats" {
+		name, stats, args = args[1], true, args[2:]
+	}
+	for i := 0; i < len(args); i++ {
+		if i+1 == len(args) {
+			return errors.New(studyUsage)
+		}
+		i++
+		var err error
+		switch args[i-1] {
+		case "-user":
+			name = args[i]
+		case "-length":
+			opts.Length, err = strconv.Atoi(args[i])
+		case "-ranks":
+			bounds := strings.SplitN(args[i], "-", 2)
+			opts.MinRank, err = strconv.Atoi(bounds[0])
+			if err == nil && len(bounds) == 2 {
+				opts.MaxRank, err = strconv.Atoi(bounds[1])
+			}
+		case "-contains":
+			opts.Containing = args[i]
+		case "-count":
+			count, err = strconv.Atoi(args[i])
+		case "-tiles":
+			ts, err = scrabble.FindTileSet(args[i])
+		default:
+			return errors.New(studyUsage)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if stats {
+		cards, err := gameDB.StudyCards(name, ts.Name)
+		if err != nil {
+			return err
+		}
+		printStudyStats(os.Stdout, name, cards)
+		return nil
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	if name == "" {
+		fmt.Print("Please enter your name: ")
+		input, _ := reader.ReadString('\n')
+		name = strings.TrimSpace(input)
+	}
+	dict, err := scrabble.LoadTileSetDictionary(ts.Dictionary, ts)
+	if err != nil {
+		return err
+	}
+	cards, err := gameDB.StudyCards(name, ts.Name)
+	if err != nil {
+		return err
+	}
+	questions := scrabble.ScheduleStudy(dict.StudySet(opts), cards, time.Now(), count)
+	if len(questions) == 0 {
+		fmt.Println("Nothing to study, every question of the set is scheduled for later")
+		return nil
+	}
+	return studySession(reader, os.Stdout, gameDB, name, ts.Name, questions, cards)
+}
+
+// studySession asks each question in turn and reschedules it from the answer, `quit` ends the session early
+func studySession(reader *bufio.Reader, out io.Writer, gameDB *scrabble.GameDB, name, lexicon string, questions []scrabble.StudyQuestion, cards map[string]scrabble.StudyCard) error {
+	fmt.Fprintln(out, "Enter every word of the letters separated by spaces, a blank line if there are none you know")
+	var asked, correct int
+	for i, q := range questions {
+		fmt.Fprintf(out, "%v/%v  %s (%v words, rank %v): ", i+1, len(questions), q.Alphagram, len(q.Answers), q.Rank)
+		input, err := reader.ReadString('\n')
+		if strings.TrimSpace(input) == "quit" || (err != nil && input == "") {
+			break
+		}
+
+		result := q.Grade(strings.Fields(input))
+		if result.Correct {
+			fmt.Fprintln(out, "Correct")
+			correct++
+		} else {
+			fmt.Fprintf(out, "Missed %v", result.Missed)
+			if len(result.Wrong) > 0 {
+				fmt.Fprintf(out, ", not words %v", result.Wrong)
+			}
+			fmt.Fprintln(out)
+		}
+		asked++
+
+		now := time.Now()
+		card, ok := cards[q.Alphagram]
+		if !ok {
+			card = scrabble.NewStudyCard(q.Alphagram, now)
+		}
+		cards[q.Alphagram] = card.Review(result.Correct, now)
+		err = gameDB.SaveStudyCard(name, lexicon, cards[q.Alphagram])
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(out, "%v of %v correct\n", correct, asked)
+	printStudyStats(out, name, cards)
+	return nil
+}
+
+// printStudyStats summarizes the cards of a user
+func printStudyStats(out io.Writer, name string, cards map[string]scrabble.StudyCard) {
+	var attempts, correct, due int
+	now := time.Now()
+	for _, c := range cards {
+		attempts += c.Attempts
+		correct += c.Correct
+		if !c.Due.After(now) {
+			due++
+		}
+	}
+	var rate float64
+	if attempts > 0 {
+		rate = float64(correct) / float64(attempts) * 100
+	}
+	fmt.Fprintf(out, "%s: %v questions studied, %v of %v answers correct (%.0f%%), %v due for review\n",
+		name, len(cards), correct, attempts, rate, due)
+}
@@ -0,0 +1,510 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	scrabble "github.com/calebice/scrabble/pkg"
+)
+
+// ANSI escape sequences used by the full screen interface
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+	resetStyle     = "\x1b[0m"
+)
+
+// Colors of the board squares, as SGR parameters
+var squareStyles = map[string]string{
+	"TW": "41;97",
+	"DW": "45;97",
+	"TL": "44;97",
+	"DL": "46;30",
+	// quadruple squares of the super board, told apart from the triples by a bold yellow label
+	"QW": "41;93;1",
+	"QL": "44;93;1",
+	"__": "100;37",
+}
+
+const (
+	tileStyle     = "43;30;1"
+	lastPlayStyle = "42;30;1"
+	pendingStyle  = "47;30;1"
+	cursorStyle   = "7"
+	maxMessages   = 200
+	shownMessages = 10
+)
+
+// keys reported by readKey for sequences that are not plain characters, above every rune
+const (
+	keyUp = iota + utf8.MaxRune + 1
+	keyDown
+	keyLeft
+	keyRight
+)
+
+// pendingTile is a tile placed with the cursor that has not been played yet
+type pendingTile struct {
+	row, col int
+	letter   string
+	blank    bool
+}
+
+// terminalUI is the full screen interface, drawn with ANSI escape codes on a raw terminal
+// everything written to it is shown in the message panel below the board
+type terminalUI struct {
+	saved     string
+	messages  []string
+	partial   string
+	row, col  int
+	across    bool
+	blankNext bool
+	pending   []pendingTile
+	status    string
+	command   *string
+	tile      *string
+	input     []byte
+	size      int
+}
+
+// useFullScreen asks whether to use the full screen interface when running in a terminal
+func useFullScreen(reader *bufio.Reader) bool {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	fmt.Print("Use the full screen interface? (y/n): ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	return input == "y" || input == "Y"
+}
+
+// startTerminalUI switches the terminal to raw mode and the alternate screen
+// raw mode is set with stty, terminals without it fall back to the line prompt
+func startTerminalUI() (*terminalUI, error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	_, err = stty("raw", "-echo")
+	if err != nil {
+		return nil, err
+	}
+	fmt.Print(enterAltScreen)
+	return &terminalUI{
+		saved:  strings.TrimSpace(saved),
+		across: true,
+	}, nil
+}
+
+// Close restores the terminal
+func (ui *terminalUI) Close() {
+	fmt.Print(leaveAltScreen)
+	stty(ui.saved)
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// Write adds output to the message panel
+func (ui *terminalUI) Write(p []byte) (int, error) {
+	text := ui.partial + strings.ReplaceAll(string(p), "\r", "")
+	lines := strings.Split(text, "\n")
+	ui.partial = lines[len(lines)-1]
+	ui.messages = append(ui.messages, lines[:len(lines)-1]...)
+	if len(ui.messages) > maxMessages {
+		ui.messages = ui.messages[len(ui.messages)-maxMessages:]
+	}
+	return len(p), nil
+}
+
+// Wait shows the final position until a key is pressed
+func (ui *terminalUI) Wait(game *scrabble.Game) {
+	ui.Render(game, "Game over, press any key")
+	ui.readKey()
+}
+
+// ReadInput lets the current player place tiles with the cursor or type a command
+// returns the input in the same form as the line prompt
+func (ui *terminalUI) ReadInput(game *scrabble.Game) string {
+	ui.pending = nil
+	ui.blankNext = false
+	ui.tile = nil
+	for {
+		ui.Render(game, "")
+		key := ui.readKey()
+
+		if ui.command != nil {
+			switch key {
+			case '\r', '\n':
+				input := *ui.command
+				ui.command = nil
+				return input
+			case 27:
+				ui.command = nil
+			case 127, 8:
+				_, size := utf8.DecodeLastRuneInString(*ui.command)
+				*ui.command = (*ui.command)[:len(*ui.command)-size]
+			default:
+				if key <= utf8.MaxRune && unicode.IsPrint(rune(key)) {
+					*ui.command += string(rune(key))
+				}
+			}
+			continue
+		}
+
+		// a tile written with several characters is typed between brackets, [CH]
+		if ui.tile != nil {
+			switch {
+			case key == ']':
+				letter := *ui.tile
+				ui.tile = nil
+				if letter != "" {
+					ui.place(game, letter)
+				}
+			case key == 27:
+				ui.tile = nil
+			case key == 127 || key == 8:
+				_, size := utf8.DecodeLastRuneInString(*ui.tile)
+				*ui.tile = (*ui.tile)[:len(*ui.tile)-size]
+			case key <= utf8.MaxRune && unicode.IsLetter(rune(key)):
+				*ui.tile += strings.ToUpper(string(rune(key)))
+			}
+			continue
+		}
+
+		switch {
+		case key == 3:
+			ui.Close()
+			os.Exit(0)
+		case key == keyUp:
+			ui.move(-1, 0)
+		case key == keyDown:
+			ui.move(1, 0)
+		case key == keyLeft:
+			ui.move(0, -1)
+		case key == keyRight:
+			ui.move(0, 1)
+		case key == '\t' || key == ' ':
+			ui.across = !ui.across
+		case key == 27:
+			ui.pending = nil
+			ui.blankNext = false
+		case key == 127 || key == 8:
+			if len(ui.pending) > 0 {
+				last := ui.pending[len(ui.pending)-1]
+				ui.pending = ui.pending[:len(ui.pending)-1]
+				ui.row, ui.col = last.row, last.col
+			}
+		case key == ':' || key == '/':
+			command := ""
+			ui.command = &command
+		case key == '[':
+			tile := ""
+			ui.tile = &tile
+		case key == '?' || key == '_':
+			ui.blankNext = true
+		case key == '\r' || key == '\n':
+			if len(ui.pending) > 0 {
+				return ui.placeInput()
+			}
+		case key <= utf8.MaxRune && unicode.IsLetter(rune(key)):
+			ui.place(game, strings.ToUpper(string(rune(key))))
+		}
+	}
+}
+
+// Render draws the board, the side panel and the messages
+func (ui *terminalUI) Render(game *scrabble.Game, status string) {
+	board := game.GetBoard()
+	// the cursor starts on the middle square of the board
+	if ui.size != board.Size() {
+		ui.size = board.Size()
+		ui.row, ui.col = ui.size/2, ui.size/2
+	}
+	last := make(map[scrabble.Coordinate]bool)
+	for _, p := range game.LastPlacements() {
+		last[p.Location] = true
+	}
+
+	var lines []string
+	header := "   "
+	for c := 1; c <= board.Size(); c++ {
+		header += fmt.Sprintf("%3v", c)
+	}
+	lines = append(lines, header)
+	for r, row := range board {
+		line := fmt.Sprintf(" %c ", 'a'+r)
+		for c, sq := range row {
+			text, style := " · ", squareStyles[sq.Multiplier]
+			if sq.Multiplier != "__" {
+				text = " " + sq.Multiplier
+			}
+			if r == board.Size()/2 && c == board.Size()/2 {
+				text = " * "
+			}
+			if !sq.IsEmpty() {
+				text, style = " "+displayLetter(sq.Value)+" ", tileStyle
+				if last[sq.Coordinate] {
+					style = lastPlayStyle
+				}
+			}
+			if p, ok := ui.pendingAt(r, c); ok {
+				text, style = " "+p+" ", pendingStyle
+			}
+			if r == ui.row && c == ui.col && ui.command == nil {
+				style += ";" + cursorStyle
+			}
+			line += fmt.Sprintf("\x1b[%sm%s%s", style, text, resetStyle)
+		}
+		lines = append(lines, line)
+	}
+
+	for i, text := range ui.panel(game) {
+		if i+1 < len(lines) {
+			lines[i+1] += "   " + text
+		}
+	}
+
+	lines = append(lines, "")
+	shown := ui.messages
+	if len(shown) > shownMessages {
+		shown = shown[len(shown)-shownMessages:]
+	}
+	lines = append(lines, shown...)
+	for i := len(shown); i < shownMessages; i++ {
+		lines = append(lines, "")
+	}
+
+	switch {
+	case ui.command != nil:
+		lines = append(lines, ":"+*ui.command+"_")
+	case ui.tile != nil:
+		lines = append(lines, "["+*ui.tile+"_")
+	case status != "":
+		lines = append(lines, status)
+	default:
+		lines = append(lines, ui.status)
+	}
+	fmt.Print(clearScreen + strings.Join(lines, "\r\n"))
+}
+
+// panel lists the scores, the rack and the preview of the pending play
+func (ui *terminalUI) panel(game *scrabble.Game) []string {
+	current := game.CurrentPlayer()
+	var lines []string
+	for _, p := range game.GetPlayers() {
+		marker := "  "
+		if p.Name == current.Name {
+			marker = "> "
+		}
+		lines = append(lines, fmt.Sprintf("%s%-12s %4v", marker, p.Name, p.Score()))
+	}
+	for _, t := range game.Teams() {
+		lines = append(lines, fmt.Sprintf("  %-12s %4v", t.Name, t.Score))
+	}
+	lines = append(lines, fmt.Sprintf("  Bag: %v tiles", len(game.Tiles.Remaining)), "")
+
+	var rack []string
+	for _, t := range ui.remainingRack(current.Tiles()) {
+		rack = append(rack, fmt.Sprintf("\x1b[%sm %s %s", tileStyle, t.Letter, resetStyle))
+	}
+	lines = append(lines, "  Rack: "+strings.Join(rack, " "))
+
+	direction := "across"
+	if !ui.across {
+		direction = "down"
+	}
+	if ui.blankNext {
+		direction += ", next letter is a blank"
+	}
+	lines = append(lines, fmt.Sprintf("  Direction: %s", direction))
+
+	preview := ""
+	if len(ui.pending) > 0 {
+		result, err := game.Preview(ui.placeInput())
+		if err != nil {
+			preview = err.Error()
+		} else {
+			preview = fmt.Sprintf("%v for %v points", result.Words, result.Score)
+		}
+	}
+	lines = append(lines, "  Preview: "+preview, "",
+		"  arrows move, letters place, ? then a letter for a blank",
+		"  tab/space direction, backspace undo, esc clear",
+		"  enter plays, : for commands (swap, moves, tiles, sim)",
+		"  ctrl-c quits",
+	)
+	return lines
+}
+
+// place puts a tile from the rack on the cursor and advances along the direction
+// letters not on the rack use a blank when one is held
+func (ui *terminalUI) place(game *scrabble.Game, letter string) {
+	board := game.GetBoard()
+	ui.skipFilled(board)
+	if ui.row >= board.Size() || ui.col >= board.Size() {
+		ui.clampCursor()
+		return
+	}
+
+	rack := ui.remainingRack(game.CurrentPlayer().Tiles())
+	var hasLetter, hasBlank bool
+	for _, t := range rack {
+		hasLetter = hasLetter || t.Letter == letter
+		hasBlank = hasBlank || t.Letter == "_"
+	}
+	blank := ui.blankNext || !hasLetter
+	ui.blankNext = false
+	if blank && !hasBlank {
+		ui.status = fmt.Sprintf("No %s on your rack", letter)
+		return
+	}
+
+	ui.status = ""
+	ui.pending = append(ui.pending, pendingTile{row: ui.row, col: ui.col, letter: letter, blank: blank})
+	if ui.across {
+		ui.col++
+	} else {
+		ui.row++
+	}
+	ui.skipFilled(board)
+	ui.clampCursor()
+}
+
+// skipFilled moves the cursor past tiles already on the board
+func (ui *terminalUI) skipFilled(board scrabble.Board) {
+	for ui.row < board.Size() && ui.col < board.Size() {
+		_, pending := ui.pendingAt(ui.row, ui.col)
+		if board[ui.row][ui.col].IsEmpty() && !pending {
+			return
+		}
+		if ui.across {
+			ui.col++
+		} else {
+			ui.row++
+		}
+	}
+}
+
+func (ui *terminalUI) move(rows, cols int) {
+	ui.row += rows
+	ui.col += cols
+	ui.clampCursor()
+}
+
+func (ui *terminalUI) clampCursor() {
+	if ui.row < 0 {
+		ui.row = 0
+	}
+	if ui.col < 0 {
+		ui.col = 0
+	}
+	if ui.row >= ui.size {
+		ui.row = ui.size - 1
+	}
+	if ui.col >= ui.size {
+		ui.col = ui.size - 1
+	}
+}
+
+func (ui *terminalUI) pendingAt(row, col int) (string, bool) {
+	for _, p := range ui.pending {
+		if p.row == row && p.col == col {
+			if p.blank {
+				return strings.ToLower(p.letter), true
+			}
+			return p.letter, true
+		}
+	}
+	return "", false
+}
+
+// remainingRack removes the pending tiles from the rack
+func (ui *terminalUI) remainingRack(rack []scrabble.Tile) []scrabble.Tile {
+	left := append([]scrabble.Tile(nil), rack...)
+	for _, p := range ui.pending {
+		for i, t := range left {
+			if (p.blank && t.Letter == "_") || (!p.blank && t.Letter == p.letter) {
+				left = append(left[:i], left[i+1:]...)
+				break
+			}
+		}
+	}
+	return left
+}
+
+// placeInput formats the pending tiles as a `place` input
+func (ui *terminalUI) placeInput() string {
+	tokens := []string{"place"}
+	for _, p := range ui.pending {
+		letter := strings.ToLower(p.letter)
+		if p.blank {
+			letter = "_" + letter
+		}
+		tokens = append(tokens, fmt.Sprintf("%s(%c,%v)", letter, 'a'+p.row, p.col+1))
+	}
+	return strings.Join(tokens, " ")
+}
+
+// readKey reads a single key press as a rune, arrow keys are reported as keyUp through keyRight
+// input is buffered so keys typed or pasted faster than the screen redraws are kept
+func (ui *terminalUI) readKey() int {
+	if len(ui.input) == 0 && !ui.readInput() {
+		return 3
+	}
+
+	if len(ui.input) >= 3 && ui.input[0] == 27 && ui.input[1] == '[' {
+		key := 0
+		switch ui.input[2] {
+		case 'A':
+			key = keyUp
+		case 'B':
+			key = keyDown
+		case 'C':
+			key = keyRight
+		case 'D':
+			key = keyLeft
+		}
+		ui.input = ui.input[3:]
+		return key
+	}
+	// a character of several bytes may be split across reads
+	for !utf8.FullRune(ui.input) {
+		if !ui.readInput() {
+			break
+		}
+	}
+	r, size := utf8.DecodeRune(ui.input)
+	ui.input = ui.input[size:]
+	return int(r)
+}
+
+// readInput appends the next bytes available on stdin to the input buffer
+func (ui *terminalUI) readInput() bool {
+	buf := make([]byte, 64)
+	n, err := os.Stdin.Read(buf)
+	if err != nil || n == 0 {
+		return false
+	}
+	ui.input = append(ui.input, buf[:n]...)
+	return true
+}
+
+// displayLetter shows blanks on the board in lower case
+func displayLetter(t scrabble.Tile) string {
+	if t.IsBlank {
+		return strings.ToLower(t.Letter)
+	}
+	return t.Letter
+}
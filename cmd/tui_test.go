@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadKey(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	// the ñ is split across two writes, as a slow terminal may deliver it
+	go func() {
+		w.Write([]byte("a\xc3"))
+		w.Write([]byte("\xb1[ch]\x1b[A"))
+		w.Close()
+	}()
+	ui := &terminalUI{}
+	var keys []int
+	for key := ui.readKey(); key != 3; key = ui.readKey() {
+		keys = append(keys, key)
+	}
+	want := []int{'a', 'ñ', '[', 'c', 'h', ']', keyUp}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("got keys %v, want %v", keys, want)
+	}
+}
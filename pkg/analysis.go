@@ -0,0 +1,302 @@
+package scrabble
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultAnalysisPlays is the number of engine plays reported for each turn
+const defaultAnalysisPlays = 3
+
+// AnalysisOptions controls a post game analysis
+// @Plays number of the engine's best plays reported for each turn, 0 for the default
+// @Leaves evaluates the plays, the default leaves when empty
+// @Dictionary words are checked against it for phonies, the game's dictionary when empty
+type AnalysisOptions struct {
+	Plays      int
+	Leaves     LeaveTable
+	Dictionary Dictionary
+}
+
+// PlayReport describes a single play within an analysis
+// @Play the word, starting square and direction, or the swapped tiles
+type PlayReport struct {
+	Play   string  `json:"play"`
+	Input  string  `json:"input"`
+	Score  int     `json:"score"`
+	Equity float64 `json:"equity"`
+	Leave  string  `json:"leave"`
+}
+
+func (p PlayReport) String() string {
+	return fmt.Sprintf("%s %v points (equity %.1f) leave %s", p.Play, p.Score, p.Equity, p.Leave)
+}
+
+// TurnAnalysis compares a played turn with the engine's best plays for the same rack
+// @ScoreLost, @EquityLost difference from the best play by equity, never negative
+// @BingoAvailable the rack could have played all of its tiles
+// @Phonies words formed by the play that are not in the dictionary
+type TurnAnalysis struct {
+	Number         int          `json:"number"`
+	Player         string       `json:"player"`
+	Rack           string       `json:"rack"`
+	Played         PlayReport   `json:"played"`
+	Best           []PlayReport `json:"best"`
+	ScoreLost      int          `json:"score_lost"`
+	EquityLost     float64      `json:"equity_lost"`
+	Bingo          bool         `json:"bingo"`
+	BingoAvailable bool         `json:"bingo_available"`
+	Phonies        []string     `json:"phonies,omitempty"`
+}
+
+// MissedBingo indicates a bingo was available but not played
+func (t TurnAnalysis) MissedBingo() bool {
+	return t.BingoAvailable && !t.Bingo
+}
+
+// PlayerAnalysis summarizes the turns of a single player
+type PlayerAnalysis struct {
+	Name              string  `json:"name"`
+	Turns             int     `json:"turns"`
+	Score             int     `json:"score"`
+	EquityLost        float64 `json:"equity_lost"`
+	AverageEquityLoss float64 `json:"average_equity_loss"`
+	BingosFound       int     `json:"bingos_found"`
+	BingosAvailable   int     `json:"bingos_available"`
+	Phonies           int     `json:"phonies"`
+}
+
+// GameAnalysis represents the annotated turns of a game and a summary per player
+type GameAnalysis struct {
+	GameID  int64            `json:"game_id"`
+	Turns   []TurnAnalysis   `json:"turns"`
+	Players []PlayerAnalysis `json:"players"`
+}
+
+func (a GameAnalysis) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Analysis of game %v\n--------------------\n", a.GameID)
+	for _, t := range a.Turns {
+		fmt.Fprintf(&b, "%v. %s [%s]\n", t.Number, t.Player, t.Rack)
+		fmt.Fprintf(&b, "   played: %s\n", t.Played)
+		for i, p := range t.Best {
+			fmt.Fprintf(&b, "   %v: %s\n", i+1, p)
+		}
+
+		var notes []string
+		if t.EquityLost > 0 {
+			notes = append(notes, fmt.Sprintf("lost %v points, %.1f equity", t.ScoreLost, t.EquityLost))
+		}
+		if t.MissedBingo() {
+			notes = append(notes, "missed bingo")
+		}
+		if len(t.Phonies) > 0 {
+			notes = append(notes, fmt.Sprintf("phony %v", t.Phonies))
+		}
+		if len(notes) == 0 {
+			notes = append(notes, "best play")
+		}
+		fmt.Fprintf(&b, "   %s\n", strings.Join(notes, ", "))
+	}
+
+	fmt.Fprintf(&b, "\nSummary\n--------------------\n")
+	for _, p := range a.Players {
+		fmt.Fprintf(&b, "%s: %v points over %v turns, average equity loss %.1f, bingos %v/%v, phonies %v\n",
+			p.Name, p.Score, p.Turns, p.AverageEquityLoss, p.BingosFound, p.BingosAvailable, p.Phonies)
+	}
+	return b.String()
+}
+
+// AnalyzeGame replays every turn of a seeded game and compares it with the engine's plays
+// each position is rebuilt from the bag so the racks are exactly those the players held
+func AnalyzeGame(game *Game, opts AnalysisOptions) (GameAnalysis, error) {
+	if opts.Plays <= 0 {
+		opts.Plays = defaultAnalysisPlays
+	}
+	if opts.Leaves.Values == nil {
+		opts.Leaves = DefaultLeaves
+	}
+	if opts.Dictionary.Words == nil {
+		opts.Dictionary = game.Dictionary
+	}
+
+	replay, err := newReplay(game)
+	if err != nil {
+		return GameAnalysis{}, err
+	}
+
+	analysis := GameAnalysis{GameID: game.id}
+	summaries := make(map[int64]*PlayerAnalysis)
+	for _, p := range replay.players {
+		analysis.Players = append(analysis.Players, PlayerAnalysis{Name: p.Name})
+	}
+	for i, p := range replay.players {
+		summaries[p.id] = &analysis.Players[i]
+	}
+
+	for _, turn := range game.Turns {
+		player := replay.CurrentPlayer()
+		pos := replay.Position()
+		moves := pos.Moves(opts.Leaves)
+
+		result, err := replay.replayTurn(turn.input)
+		if err != nil {
+			return analysis, ErrVerificationFailed{
+				Turn:   turn.number,
+				Reason: fmt.Sprintf("could not replay %q: %v", turn.input, err),
+			}
+		}
+
+		t := TurnAnalysis{
+			Number: turn.number,
+			Player: player.Name,
+			Rack:   leaveKey(pos.Rack()),
+			Played: analyzePlayed(pos, moves, turn.input, result, opts.Leaves),
+		}
+		for _, m := range moves {
+			if len(m.Placements) == pos.Rules.RackSize {
+				t.BingoAvailable = true
+			}
+			if len(t.Best) < opts.Plays {
+				t.Best = append(t.Best, m.Report())
+			}
+		}
+		t.Bingo = isBingo(result, pos.Rules.RackSize)
+		for _, w := range result.Words {
+			if !pos.Rules.isWord(opts.Dictionary, w.Letters()) {
+				t.Phonies = append(t.Phonies, w.String())
+			}
+		}
+		t.Phonies = append(t.Phonies, result.Invalid...)
+		if len(moves) > 0 && moves[0].Equity > t.Played.Equity {
+			t.EquityLost = moves[0].Equity - t.Played.Equity
+			if moves[0].Score > t.Played.Score {
+				t.ScoreLost = moves[0].Score - t.Played.Score
+			}
+		}
+		analysis.Turns = append(analysis.Turns, t)
+
+		summary := summaries[player.id]
+		summary.Turns++
+		summary.Score += result.Score
+		summary.EquityLost += t.EquityLost
+		summary.Phonies += len(t.Phonies)
+		if t.Bingo {
+			summary.BingosFound++
+		}
+		if t.BingoAvailable {
+			summary.BingosAvailable++
+		}
+	}
+
+	for i, p := range analysis.Players {
+		if p.Turns > 0 {
+			analysis.Players[i].AverageEquityLoss = p.EquityLost / float64(p.Turns)
+		}
+	}
+	return analysis, nil
+}
+
+// analyzePlayed evaluates the play that was made from the position
+// placements are matched against the generated moves, swaps and withdrawn plays are worth only their leave
+func analyzePlayed(pos Position, moves []Move, input string, result Result, leaves LeaveTable) PlayReport {
+	leaves = leaves.forRules(pos.Rules)
+	tokens := strings.Fields(input)
+	if result.Action == "challenged" {
+		return PlayReport{
+			Play:   "withdrawn",
+			Input:  input,
+			Equity: leaves.Value(pos.Rack()),
+			Leave:  leaveKey(pos.Rack()),
+		}
+	}
+	if result.Action == "swap" {
+		kept := removeTiles(pos.Rack(), pos.Dictionary.TileSet().parseTiles(tokens[1:]))
+		play := "pass"
+		if len(tokens) > 1 {
+			play = fmt.Sprintf("swap %s", strings.ToUpper(strings.Join(tokens[1:], "")))
+		}
+		return PlayReport{
+			Play:   play,
+			Input:  input,
+			Equity: leaves.Value(kept),
+			Leave:  leaveKey(kept),
+		}
+	}
+
+	placements, _ := pos.Dictionary.TileSet().parseTilePlacements(tokens[1:])
+	for _, m := range moves {
+		if samePlacements(m.Placements, placements) {
+			return m.Report()
+		}
+	}
+
+	// plays the generator does not know, such as phonies, are valued directly
+	var played []Tile
+	for _, p := range placements {
+		played = append(played, p.Tile)
+	}
+	move := Move{
+		Placements: placements,
+		Score:      result.Score,
+		Leave:      removeTiles(pos.Rack(), played),
+	}
+	var words []string
+	for _, w := range result.Words {
+		words = append(words, w.String())
+	}
+	report := move.Report()
+	report.Play = strings.Join(words, ",")
+	report.Equity = leaves.Equity(pos.Board, move, pos.BagSize)
+	return report
+}
+
+// Report describes the move as a play within an analysis
+func (m Move) Report() PlayReport {
+	return PlayReport{
+		Play:   m.Notation(),
+		Input:  m.Input(),
+		Score:  m.Score,
+		Equity: m.Equity,
+		Leave:  leaveKey(m.Leave),
+	}
+}
+
+// isBingo checks whether a play laid every tile of a full rack
+func isBingo(result Result, rackSize int) bool {
+	return result.Action == "place" && result.Placed == rackSize
+}
+
+// removeTiles returns the rack without the provided tiles, placed blanks remove a blank
+func removeTiles(rack, tiles []Tile) []Tile {
+	left := append([]Tile(nil), rack...)
+	for _, t := range tiles {
+		for i, held := range left {
+			if (t.IsBlank && held.Letter == "_") || (!t.IsBlank && held.Letter == t.Letter) {
+				left = append(left[:i], left[i+1:]...)
+				break
+			}
+		}
+	}
+	return left
+}
+
+// samePlacements compares two sets of placements regardless of their order
+func samePlacements(a, b []TilePlacement) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, p := range a {
+		var found bool
+		for _, q := range b {
+			if p == q {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
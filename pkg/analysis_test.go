@@ -0,0 +1,54 @@
+package scrabble
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeGame(t *testing.T) {
+	game := newFairGame(t, 42, nil)
+	// bob moves first from this seed and makes the worst play, alice plays a phony that is withdrawn,
+	// then bob makes the best play
+	moves := game.Moves(DefaultLeaves)
+	worst := moves[len(moves)-1]
+	for _, input := range []string{worst.Notation(), "h9 XN"} {
+		if _, err := game.ApplyTurn(input, nil); err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+	}
+	if _, err := game.ApplyTurn(game.Moves(DefaultLeaves)[0].Notation(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	analysis, err := AnalyzeGame(game, AnalysisOptions{Plays: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analysis.Turns) != 3 {
+		t.Fatalf("%v turns analyzed, want 3", len(analysis.Turns))
+	}
+	first, phony, best := analysis.Turns[0], analysis.Turns[1], analysis.Turns[2]
+	if first.Played.Play != worst.Notation() || first.Best[0].Play != moves[0].Notation() || len(first.Best) != 2 {
+		t.Errorf("first turn played %s with best %v", first.Played.Play, first.Best)
+	}
+	if first.ScoreLost != moves[0].Score-worst.Score || first.EquityLost != moves[0].Equity-worst.Equity {
+		t.Errorf("first turn lost %v points and %.1f equity", first.ScoreLost, first.EquityLost)
+	}
+	if phony.Played.Play != "withdrawn" || len(phony.Phonies) != 1 || !strings.Contains(phony.Phonies[0], "RXN") {
+		t.Errorf("phony turn played %s with phonies %v", phony.Played.Play, phony.Phonies)
+	}
+	if best.EquityLost != 0 || best.ScoreLost != 0 {
+		t.Errorf("best play lost %v points and %.1f equity", best.ScoreLost, best.EquityLost)
+	}
+
+	bob, alice := analysis.Players[0], analysis.Players[1]
+	if bob.Name != "bob" || bob.Turns != 2 || bob.AverageEquityLoss != first.EquityLost/2 || bob.Phonies != 0 {
+		t.Errorf("bob: %+v", bob)
+	}
+	if alice.Turns != 1 || alice.Phonies != 1 || alice.Score != 0 {
+		t.Errorf("alice: %+v", alice)
+	}
+	if report := analysis.String(); !strings.Contains(report, "best play") || !strings.Contains(report, "phony") {
+		t.Errorf("report does not annotate the turns:\n%s", report)
+	}
+}
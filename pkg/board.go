@@ -2,54 +2,69 @@ package scrabble
 
 import (
 	"fmt"
+	"strings"
 )
 
-// Board represents the view of the scrabble board
-type Board [Size][Size]Square
+// Board represents the view of the scrabble board, indexed by row then column
+// its size and premium squares follow the layout it was created from
+type Board [][]Square
 
 var wordMult = map[string]int{
 	"DW": 2,
 	"TW": 3,
+	"QW": 4,
 }
 var letterMult = map[string]int{
 	"__": 1,
 	"DL": 2,
 	"TL": 3,
+	"QL": 4,
 }
 
-var board = [][]string{
-	{"TW", "__", "__", "DL", "__", "__", "__", "TW", "__", "__", "__", "DL", "__", "__", "TW"},
-	{"__", "DW", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "DW", "__"},
-	{"__", "__", "DW", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DW", "__", "__"},
-	{"DL", "__", "__", "DW", "__", "__", "__", "DL", "__", "__", "__", "DW", "__", "__", "DL"},
-	{"__", "__", "__", "__", "DW", "__", "__", "__", "__", "__", "DW", "__", "__", "__", "__"},
-	{"__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__"},
-	{"__", "__", "DL", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DL", "__", "__"},
-	{"TW", "__", "__", "DL", "__", "__", "__", "DW", "__", "__", "__", "DL", "__", "__", "TW"},
-	{"__", "__", "DL", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DL", "__", "__"},
-	{"__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__"},
-	{"__", "__", "__", "__", "DW", "__", "__", "__", "__", "__", "DW", "__", "__", "__", "__"},
-	{"DL", "__", "__", "DW", "__", "__", "__", "DL", "__", "__", "__", "DW", "__", "__", "DL"},
-	{"__", "__", "DW", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DW", "__", "__"},
-	{"__", "DW", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "DW", "__"},
-	{"TW", "__", "__", "DL", "__", "__", "__", "TW", "__", "__", "__", "DL", "__", "__", "TW"},
-}
-
-// NewBoard returns an instantiated board with the proper multipliers
+// NewBoard returns an instantiated standard board with the proper multipliers
 func NewBoard() Board {
-	var Board Board
-	for i, row := range board {
-		for j, val := range row {
-			Board[i][j] = Square{
-				Multiplier: val,
-				Coordinate: Coordinate{i, j},
-			}
+	return StandardLayout.NewBoard()
+}
+
+// Size returns the number of rows and columns of the board
+func (b Board) Size() int {
+	return len(b)
+}
+
+// Center returns the square the first play must cover
+func (b Board) Center() Coordinate {
+	return Coordinate{len(b) / 2, len(b) / 2}
+}
+
+// onBoard checks a coordinate lies within the board
+func (b Board) onBoard(x, y int) bool {
+	return x >= 0 && y >= 0 && x < len(b) && y < len(b)
+}
+
+// Copy returns a board that can be changed without affecting this one
+func (b Board) Copy() Board {
+	size := len(b)
+	squares := make([]Square, size*size)
+	board := make(Board, size)
+	for i, row := range b {
+		board[i] = squares[i*size : (i+1)*size]
+		copy(board[i], row)
+	}
+	return board
+}
+
+// Layout returns the premium squares of the board, without the tiles placed on it
+func (b Board) Layout() Layout {
+	layout := Layout{Premiums: make([][]string, len(b))}
+	for i, row := range b {
+		for _, s := range row {
+			layout.Premiums[i] = append(layout.Premiums[i], s.Multiplier)
 		}
 	}
-	return Board
+	return layout
 }
 
-func (b *Board) setCoordinates() {
+func (b Board) setCoordinates() {
 	for i, row := range b {
 		for j := range row {
 			b[i][j].Coordinate = Coordinate{i, j}
@@ -63,13 +78,13 @@ func (b Board) FormatPrint(usePlainText bool) string {
 	if usePlainText {
 		// Case of printing to plain text (spaces/letters have different sizes)
 		str = fmt.Sprint("    ")
-		for i := 1; i <= Size; i++ {
-			switch i {
-			case 1, 6:
+		for i := 1; i <= len(b); i++ {
+			switch {
+			case i == 1, i == 6:
 				str = fmt.Sprintf("%s |_%v|  ", str, i)
-			case 12, 14:
+			case i == 12, i == 14, i > 15:
 				str = fmt.Sprintf("%s|%v| ", str, i)
-			case 10, 11, 13, 15:
+			case i > 9:
 				str = fmt.Sprintf("%s |%v| ", str, i)
 			default:
 				str = fmt.Sprintf("%s|_%v| ", str, i)
@@ -99,7 +114,7 @@ func (b Board) FormatPrint(usePlainText bool) string {
 func (b Board) String() string {
 	var str string
 	str = fmt.Sprint("   ")
-	for i := 1; i <= Size; i++ {
+	for i := 1; i <= len(b); i++ {
 		if i > 9 {
 			str = fmt.Sprintf("%s|%2v| ", str, i)
 		} else {
@@ -117,6 +132,27 @@ func (b Board) String() string {
 	return str
 }
 
+// Rows writes each row of the board as a word, `.` for empty squares and lower case for blanks
+// letters of several characters are bracketed so every square can be told apart ([CH])
+func (b Board) Rows() []string {
+	var rows []string
+	for _, row := range b {
+		var squares strings.Builder
+		for _, sq := range row {
+			switch {
+			case sq.IsEmpty():
+				squares.WriteString(".")
+			case sq.Value.IsBlank:
+				squares.WriteString(writtenLetter(strings.ToLower(sq.Value.Letter)))
+			default:
+				squares.WriteString(writtenLetter(sq.Value.Letter))
+			}
+		}
+		rows = append(rows, squares.String())
+	}
+	return rows
+}
+
 // Square represents an individual unit on the board
 // @Value occupying Tile
 // @Multiplier multiplier to apply to letter or word
@@ -143,7 +179,7 @@ func (s Square) IsEmpty() bool {
 
 // SetSquareUsed indicates that multipliers have been applied to provided coordinate
 // prevents duplicate multiplier applications
-func (b *Board) SetSquareUsed(coordinate Coordinate) {
+func (b Board) SetSquareUsed(coordinate Coordinate) {
 	b[coordinate.x][coordinate.y].Used = true
 }
 
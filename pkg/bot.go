@@ -0,0 +1,114 @@
+package scrabble
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// BotLevel represents the strength of a computer controlled player
+type BotLevel string
+
+// Supported bot levels, from weakest to strongest
+const (
+	// BotEasy plays a middling move by score
+	BotEasy BotLevel = "easy"
+	// BotMedium plays the highest scoring move
+	BotMedium BotLevel = "medium"
+	// BotHard plays the move with the best equity
+	BotHard BotLevel = "hard"
+	// BotExpert simulates the best equity moves and plays the most likely winner
+	BotExpert BotLevel = "expert"
+)
+
+// BotLevels maps names to bot levels, used for parsing player input
+var BotLevels = map[string]BotLevel{
+	string(BotEasy):   BotEasy,
+	string(BotMedium): BotMedium,
+	string(BotHard):   BotHard,
+	string(BotExpert): BotExpert,
+}
+
+// Time the stronger bots spend thinking before each move
+const (
+	expertSimTime = 3 * time.Second
+	endgameTime   = 2 * time.Second
+)
+
+// Bot chooses moves for a computer controlled player
+type Bot struct {
+	Level  BotLevel
+	Leaves LeaveTable
+	Sim    SimOptions
+}
+
+// NewBot creates a bot of the provided level using the default leaves
+func NewBot(level BotLevel) Bot {
+	return Bot{
+		Level:  level,
+		Leaves: DefaultLeaves,
+		Sim: SimOptions{
+			Duration: expertSimTime,
+		},
+	}
+}
+
+// ChooseMove picks a play for the player to move
+// returns false when the bot has no legal placement or prefers to pass
+func (b Bot) ChooseMove(pos Position) (Move, bool) {
+	leaves := b.Leaves
+	if leaves.Values == nil {
+		leaves = DefaultLeaves
+	}
+	moves := pos.Moves(leaves)
+	if len(moves) == 0 {
+		return Move{}, false
+	}
+
+	// once the bag is empty the stronger bots solve the endgame instead
+	if (b.Level == BotHard || b.Level == BotExpert) && pos.BagSize == 0 && len(pos.Racks) == 2 {
+		result, err := SolveEndgame(pos, EndgameOptions{Duration: endgameTime})
+		if err == nil && len(result.Moves) > 0 {
+			return result.Moves[0], len(result.Moves[0].Placements) > 0
+		}
+	}
+
+	switch b.Level {
+	case BotEasy:
+		sort.Sort(ByScore(moves))
+		return moves[len(moves)/2], true
+	case BotMedium:
+		sort.Sort(ByScore(moves))
+		return moves[0], true
+	case BotExpert:
+		if len(moves) == 1 {
+			return moves[0], true
+		}
+		if len(moves) > defaultSimCandidates {
+			moves = moves[:defaultSimCandidates]
+		}
+		opts := b.Sim
+		opts.Leaves = leaves
+		return Simulate(pos, moves, opts)[0].Move, true
+	}
+	return moves[0], true
+}
+
+// Turn decides the input for the current player of the game
+// a bot without a placement swaps its whole rack, or passes when the bag is too small
+func (b Bot) Turn(game *Game) string {
+	move, ok := b.ChooseMove(game.Position())
+	if ok {
+		return move.Input()
+	}
+
+	rack := game.CurrentPlayer().Tiles()
+	if len(game.Tiles.Remaining) < len(rack) {
+		return "swap"
+	}
+	tokens := []string{"swap"}
+	for _, t := range rack {
+		tokens = append(tokens, t.Letter)
+	}
+	return strings.Join(tokens, " ")
+}
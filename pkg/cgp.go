@@ -0,0 +1,359 @@
+package scrabble
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// CGP represents a whole position on a single line of text, modelled on the CGP notation of other crossword game programs
+// `15/15/15/15/15/15/15/7QUIXOTIc/15/15/15/15/15/15/15 AEINRS?/ 0/116 0 lex english;`
+// the rows of the board separated by `/`, runs of empty squares written as their count and blanks in lower case,
+// then the racks and the scores separated by `/`, the scoreless turns in a row, and operations ending in `;`
+// @Racks the rack of every player in turn order, the player to move first, empty when not known
+// @Scores of every player in the order of the racks
+// @Zeros scoreless turns in a row that led to the position
+// @Lexicon name of the word list (`lex`), the word lists here are named after their tile set,
+// the word list of the tile set is played with when the lexicon is one of another program (NWL20, CSW21)
+// @Rules rules of the position, naming its board layout and tile set (`tiles`, or the tile set of a known lexicon)
+type CGP struct {
+	Board   Board
+	Racks   [][]Tile
+	Scores  []int
+	Zeros   int
+	Lexicon string
+	Rules   RuleSet
+}
+
+// CGP captures the position of the game with every rack, the player to move first
+func (game *Game) CGP() CGP {
+	start := 0
+	for i, p := range game.players {
+		if p.id == game.CurrentPlayer().id {
+			start = i
+		}
+	}
+	c := CGP{
+		Board: game.board.Copy(),
+		Zeros: game.ScorelessTurns(),
+		Rules: game.Rules(),
+	}
+	c.Rules.TileSet = game.TileSet().Name
+	c.Lexicon = c.Rules.TileSet
+	for i := range game.players {
+		p := game.players[(start+i)%len(game.players)]
+		c.Racks = append(c.Racks, append([]Tile(nil), p.tiles...))
+		c.Scores = append(c.Scores, p.score)
+	}
+	return c
+}
+
+// Seen leaves out the racks of every player but the one to move, the position as that player sees it
+func (c CGP) Seen() CGP {
+	racks := make([][]Tile, len(c.Racks))
+	if len(racks) > 0 {
+		racks[0] = c.Racks[0]
+	}
+	c.Racks = racks
+	return c
+}
+
+func (c CGP) String() string {
+	racks := make([]string, len(c.Racks))
+	for i, rack := range c.Racks {
+		racks[i] = strings.ReplaceAll(tileLetters(rack), "_", "?")
+	}
+	scores := make([]string, len(c.Scores))
+	for i, score := range c.Scores {
+		scores[i] = strconv.Itoa(score)
+	}
+
+	rules := c.Rules.orDefault()
+	lexicon := c.Lexicon
+	if lexicon == "" {
+		lexicon = rules.TileSet
+	}
+	text := fmt.Sprintf("%s %s %s %v lex %s;", c.Board.CGP(), strings.Join(racks, "/"), strings.Join(scores, "/"), c.Zeros, lexicon)
+	if rules.TileSet != lexicon {
+		text += fmt.Sprintf(" tiles %s;", rules.TileSet)
+	}
+	if rules.Name != CasualRules.Name {
+		text += fmt.Sprintf(" rules %s;", rules.Name)
+	}
+	// the layout is only written when it is not the board of the rules, a layout file is left out
+	// when its path would break the line, the position is then read on the board of the rules
+	preset, err := FindRuleSet(rules.Name)
+	if (err != nil || preset.Layout != rules.Layout) && rules.Layout != "" && !strings.ContainsAny(rules.Layout, "; \t\n") {
+		text += fmt.Sprintf(" layout %s;", rules.Layout)
+	}
+	return text
+}
+
+// CGP writes the rows of the board on one line separated by `/`, runs of empty squares as their count
+func (b Board) CGP() string {
+	rows := b.Rows()
+	for i, row := range rows {
+		var squares strings.Builder
+		var empty int
+		for _, r := range row {
+			if r == '.' {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				squares.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			squares.WriteRune(r)
+		}
+		if empty > 0 {
+			squares.WriteString(strconv.Itoa(empty))
+		}
+		rows[i] = squares.String()
+	}
+	return strings.Join(rows, "/")
+}
+
+// ParseCGPBoard reads the rows of a board written by Board.CGP onto an empty board of the layout
+func ParseCGPBoard(text string, layout Layout, ts TileSet) (Board, error) {
+	var rows []string
+	for _, row := range strings.Split(text, "/") {
+		var squares strings.Builder
+		var empty int
+		for _, r := range row {
+			if r >= '0' && r <= '9' {
+				empty = empty*10 + int(r-'0')
+				continue
+			}
+			squares.WriteString(strings.Repeat(".", empty))
+			empty = 0
+			squares.WriteRune(r)
+		}
+		squares.WriteString(strings.Repeat(".", empty))
+		rows = append(rows, squares.String())
+	}
+	return SetupBoard(layout, ts, rows, nil)
+}
+
+// ParseCGP reads a position written by CGP.String
+// operations other programs write, such as timers, are skipped
+func ParseCGP(text string) (CGP, error) {
+	fields := strings.Fields(text)
+	if len(fields) < 4 {
+		return CGP{}, ErrCGP{Reason: "expected the board, the racks, the scores and the scoreless turns"}
+	}
+
+	rules := CasualRules
+	var lexicon, tiles, layoutName string
+	for _, op := range strings.Split(strings.Join(fields[4:], " "), ";") {
+		args := strings.Fields(op)
+		if len(args) == 0 {
+			continue
+		}
+		switch args[0] {
+		case "lex", "tiles", "rules", "layout":
+			if len(args) != 2 {
+				return CGP{}, ErrCGP{Reason: fmt.Sprintf("%s needs a single name", args[0])}
+			}
+		default:
+			continue
+		}
+		switch args[0] {
+		case "lex":
+			lexicon = args[1]
+		case "tiles":
+			tiles = args[1]
+		case "rules":
+			var err error
+			rules, err = FindRuleSet(args[1])
+			if err != nil {
+				return CGP{}, err
+			}
+		case "layout":
+			layoutName = args[1]
+		}
+	}
+	// a lexicon of another program keeps the tile set of the rules
+	if _, err := FindTileSet(lexicon); tiles == "" && lexicon != "" && err == nil {
+		tiles = lexicon
+	}
+	if tiles != "" {
+		rules.TileSet = tiles
+	}
+	if layoutName != "" {
+		rules.Layout = layoutName
+	}
+	ts, layout, err := rules.TileSetLayout()
+	if err != nil {
+		return CGP{}, err
+	}
+	rules.Layout, rules.TileSet = layout.Name, ts.Name
+	if lexicon == "" {
+		lexicon = ts.Name
+	}
+
+	c := CGP{Lexicon: lexicon, Rules: rules}
+	c.Board, err = ParseCGPBoard(fields[0], layout, ts)
+	if err != nil {
+		return CGP{}, err
+	}
+	for _, letters := range strings.Split(fields[1], "/") {
+		rack, err := parseSetupTiles(ts, letters)
+		if err != nil {
+			return CGP{}, err
+		}
+		if len(rack) > rules.RackSize {
+			return CGP{}, ErrCGP{Reason: fmt.Sprintf("a rack holds at most %v tiles, %s has %v", rules.RackSize, letters, len(rack))}
+		}
+		c.Racks = append(c.Racks, rack)
+	}
+	if len(c.Racks) > 4 {
+		return CGP{}, ErrCGP{Reason: "a game has 1 to 4 players"}
+	}
+	for _, s := range strings.Split(fields[2], "/") {
+		score, err := strconv.Atoi(s)
+		if err != nil {
+			return CGP{}, ErrCGP{Reason: fmt.Sprintf("score %q is not a number", s)}
+		}
+		c.Scores = append(c.Scores, score)
+	}
+	if len(c.Scores) != len(c.Racks) {
+		return CGP{}, ErrCGP{Reason: fmt.Sprintf("%v racks but %v scores", len(c.Racks), len(c.Scores))}
+	}
+	c.Zeros, err = strconv.Atoi(fields[3])
+	if err != nil || c.Zeros < 0 {
+		return CGP{}, ErrCGP{Reason: fmt.Sprintf("scoreless turns %q is not a count", fields[3])}
+	}
+
+	_, err = c.pool(ts)
+	return c, err
+}
+
+// NewGame starts a game from the position, the players are seated in the order of the racks
+// racks left empty are drawn from the tiles on neither the board nor a rack, the rest fill the bag in a random order
+// the scoreless turns are recorded as passes so the limit of the rules carries on from the position
+// the word list is that of the tile set, the lexicon of another program is only kept in the position
+// the bag is ordered, so the game cannot be verified or replayed from its seed
+func (c CGP) NewGame(playerReq []PlayerRequest, gameDB *GameDB) (*Game, error) {
+	if len(playerReq) != len(c.Racks) {
+		return nil, ErrCGP{Reason: fmt.Sprintf("%v players given for the %v racks of the position", len(playerReq), len(c.Racks))}
+	}
+	rules := c.Rules.orDefault()
+	rules.SharedRack = false
+	ts, _, err := rules.TileSetLayout()
+	if err != nil {
+		return nil, err
+	}
+	dict, err := gameDB.loadDictionary(ts)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := c.pool(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	var seed int64
+	for seed == 0 {
+		seed = rand.Int63()
+	}
+	rand.New(rand.NewSource(seed)).Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+	racks := make([][]Tile, len(c.Racks))
+	for i, rack := range c.Racks {
+		if len(rack) == 0 {
+			n := rules.RackSize
+			if n > len(pool) {
+				n = len(pool)
+			}
+			rack, pool = pool[:n], pool[n:]
+		}
+		racks[i] = append([]Tile(nil), rack...)
+	}
+
+	// players are dealt from an empty bag, then given the racks of the position
+	game := Game{
+		board:      c.Board.Copy(),
+		players:    []Player{},
+		Tiles:      NewOrderedTiles(nil),
+		Dictionary: dict,
+		rules:      rules,
+	}
+	requests := make([]PlayerRequest, len(playerReq))
+	copy(requests, playerReq)
+	for i := range requests {
+		requests[i].Team = ""
+	}
+	err = game.addPlayersInOrder(requests, gameDB)
+	if err != nil {
+		return nil, err
+	}
+	for i := range game.players {
+		game.players[i].tiles = racks[i]
+		game.players[i].score = c.Scores[i]
+	}
+	game.Tiles = NewOrderedTiles(pool)
+	game.Tiles.seed = seed
+	if ts.Name != EnglishTiles.Name {
+		game.Tiles.Set = ts.Name
+	}
+	game.commit()
+
+	if gameDB != nil {
+		err = gameDB.UpsertGame(&game)
+		if err != nil {
+			return nil, err
+		}
+	}
+	n := len(game.players)
+	for i := 0; i < c.Zeros; i++ {
+		// the passes are taken in turn by the players before the one to move
+		turn := Turn{
+			number: i + 1,
+			input:  "swap",
+			player: game.players[((i-c.Zeros)%n+n)%n],
+		}
+		if gameDB != nil {
+			err = gameDB.InsertTurn(turn)
+			if err != nil {
+				return nil, err
+			}
+		}
+		game.Turns = append(game.Turns, turn)
+	}
+	game.Turn = Turn{
+		number: c.Zeros + 1,
+		player: game.players[0],
+	}
+	return &game, nil
+}
+
+// pool lists the tiles of the set on neither the board nor a rack
+func (c CGP) pool(ts TileSet) ([]Tile, error) {
+	counts := make(map[string]int)
+	for letter, count := range ts.Counts {
+		counts[letter] = count
+	}
+	for _, row := range c.Board {
+		for _, s := range row {
+			if !s.IsEmpty() {
+				counts[tileKey(s.Value)]--
+			}
+		}
+	}
+	for _, rack := range c.Racks {
+		for _, t := range rack {
+			counts[tileKey(t)]--
+		}
+	}
+	for letter, count := range counts {
+		if count < 0 {
+			// blanks are named as the position writes them
+			name := strings.ReplaceAll(writtenLetter(letter), "_", "?")
+			return nil, ErrCGP{Reason: fmt.Sprintf("the %s set has only %v %s tiles", ts.Name, ts.Counts[letter], name)}
+		}
+	}
+	return summarizeUnseen(counts, ts).Tiles(), nil
+}
@@ -0,0 +1,193 @@
+package scrabble
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// roundTrip writes a position and reads it back, failing the test when it cannot be read
+func roundTrip(t *testing.T, c CGP) CGP {
+	t.Helper()
+	parsed, err := ParseCGP(c.String())
+	if err != nil {
+		t.Fatalf("%s: %v", c, err)
+	}
+	return parsed
+}
+
+func cgpTiles(t *testing.T, ts TileSet, letters string) []Tile {
+	t.Helper()
+	tiles, err := parseSetupTiles(ts, letters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tiles
+}
+
+func TestCGPRoundTrip(t *testing.T) {
+	board, err := SetupBoard(StandardLayout, EnglishTiles, nil, []string{"h7 QUIT", "1a ZeSTS", "15o A", "12e ETA"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := CGP{
+		Board:  board,
+		Racks:  [][]Tile{cgpTiles(t, EnglishTiles, "AEIRS?"), cgpTiles(t, EnglishTiles, "DOG")},
+		Scores: []int{116, -8},
+		Zeros:  3,
+		Rules:  CasualRules,
+	}
+	text := c.String()
+	// runs of empty squares of 10 and more are written with two digits
+	for _, row := range []string{"/S10E3/11T3/11A3/6QUIT5/15/", "/14A "} {
+		if !strings.Contains(text, row) {
+			t.Errorf("%s: missing the row %s", text, row)
+		}
+	}
+	if !strings.HasPrefix(text, "Z14/e14/S14/T14/") {
+		t.Errorf("%s: blanks should be in lower case", text)
+	}
+	if !strings.Contains(text, " AEIRS?/DOG 116/-8 3 lex english;") {
+		t.Errorf("%s: racks, scores or scoreless turns", text)
+	}
+
+	parsed := roundTrip(t, c)
+	if parsed.String() != text {
+		t.Errorf("written again as %s, was %s", parsed, text)
+	}
+	for x, row := range board {
+		for y, s := range row {
+			if parsed.Board[x][y].Value != s.Value {
+				t.Errorf("square (%v,%v): read %v, wrote %v", x, y, parsed.Board[x][y].Value, s.Value)
+			}
+		}
+	}
+	if !parsed.Board[1][0].Value.IsBlank || parsed.Board[1][0].Value.Value != 0 {
+		t.Errorf("blank e read as %v", parsed.Board[1][0].Value)
+	}
+	if !reflect.DeepEqual(parsed.Racks, c.Racks) || !reflect.DeepEqual(parsed.Scores, c.Scores) || parsed.Zeros != 3 {
+		t.Errorf("read racks %v scores %v zeros %v", parsed.Racks, parsed.Scores, parsed.Zeros)
+	}
+
+	// a larger board has runs of more than 15 empty squares
+	c = CGP{Board: SuperLayout.NewBoard(), Racks: [][]Tile{nil, nil}, Scores: []int{0, 0}, Rules: CasualRules}
+	c.Rules.Layout = SuperLayout.Name
+	size := strings.Repeat("/"+strconv.Itoa(SuperLayout.Size()), SuperLayout.Size())[1:]
+	if text := c.String(); !strings.HasPrefix(text, size+" / 0/0 0 lex english; layout super;") {
+		t.Errorf("empty super board: got %s", text)
+	}
+	if parsed := roundTrip(t, c); parsed.Board.Size() != SuperLayout.Size() || parsed.Rules.Layout != SuperLayout.Name {
+		t.Errorf("empty super board: read a board of %v on %v", parsed.Board.Size(), parsed.Rules.Layout)
+	}
+}
+
+func TestCGPOperations(t *testing.T) {
+	empty := "15/15/15/15/15/15/15/15/15/15/15/15/15/15/15 / 0/0 0"
+	tests := []struct {
+		name    string
+		ops     string
+		lexicon string
+		tiles   string
+		rules   string
+		layout  string
+	}{
+		{"none", "", "english", "english", "casual", "standard"},
+		{"lexicon of a tile set", "lex spanish;", "spanish", "spanish", "casual", "standard"},
+		{"lexicon of another program", "lex NWL20;", "NWL20", "english", "casual", "standard"},
+		{"tiles with another lexicon", "lex FISE2; tiles spanish;", "FISE2", "spanish", "casual", "standard"},
+		{"rules", "lex english; rules classic;", "english", "english", "classic", "standard"},
+		{"rules with their board and tiles", "lex CSW21; rules friends;", "CSW21", "friends", "friends", "friends"},
+		{"layout", "lex english; layout friends;", "english", "english", "casual", "friends"},
+		{"other operations", "lex english; tmr 100/200; bdn 4; rules clabbers;", "english", "english", "clabbers", "standard"},
+	}
+	for _, test := range tests {
+		c, err := ParseCGP(empty + " " + test.ops)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if c.Lexicon != test.lexicon || c.Rules.TileSet != test.tiles || c.Rules.Name != test.rules || c.Rules.Layout != test.layout {
+			t.Errorf("%s: got lex %v tiles %v rules %v layout %v", test.name, c.Lexicon, c.Rules.TileSet, c.Rules.Name, c.Rules.Layout)
+		}
+		again := roundTrip(t, c)
+		if again.Lexicon != c.Lexicon || again.Rules != c.Rules {
+			t.Errorf("%s: written as %s, read back as %+v", test.name, c, again.Rules)
+		}
+	}
+
+	for _, ops := range []string{"lex;", "lex a b;", "rules tournament;", "tiles klingon;", "layout missing.txt;"} {
+		if _, err := ParseCGP(empty + " " + ops); err == nil {
+			t.Errorf("%s: should not be read", ops)
+		}
+	}
+}
+
+func TestCGPRejects(t *testing.T) {
+	row := "15/15/15/15/15/15/15/%s/15/15/15/15/15/15/15"
+	tests := map[string]string{
+		"two Z on the board":    strings.Replace(row, "%s", "6ZZ7", 1) + " / 0/0 0",
+		"Z on board and rack":   strings.Replace(row, "%s", "7Z7", 1) + " Z/ 0/0 0",
+		"three blanks":          strings.Replace(row, "%s", "7a7", 1) + " ??/ 0/0 0",
+		"rack of eight":         strings.Replace(row, "%s", "15", 1) + " AEINRSTT/ 0/0 0",
+		"more racks than score": strings.Replace(row, "%s", "15", 1) + " A/B/C 0/0 0",
+		"score":                 strings.Replace(row, "%s", "15", 1) + " / 0/x 0",
+		"negative zeros":        strings.Replace(row, "%s", "15", 1) + " / 0/0 -1",
+		"five players":          strings.Replace(row, "%s", "15", 1) + " A/B/C/D/E 0/0/0/0/0 0",
+		"missing fields":        strings.Replace(row, "%s", "15", 1) + " /",
+	}
+	for name, text := range tests {
+		if _, err := ParseCGP(text); err == nil {
+			t.Errorf("%s: %s should not be read", name, text)
+		}
+	}
+	for name, want := range map[string]string{"two Z on the board": "only 1 Z tiles", "three blanks": "only 2 ? tiles"} {
+		_, err := ParseCGP(tests[name])
+		if _, ok := err.(ErrCGP); !ok || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: got %v, want a position error with %q", name, err, want)
+		}
+	}
+}
+
+func TestCGPNewGame(t *testing.T) {
+	c, err := ParseCGP("15/15/15/15/15/15/15/6CAT6/15/15/15/15/15/15/15 AEINRS?//DOG 10/20/30 4 lex english;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, err := c.NewGame([]PlayerRequest{{Name: "alice"}, {Name: "bob"}, {Name: "carol"}}, newTestDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.CurrentPlayer().Name != "alice" || leaveKey(game.CurrentPlayer().tiles) != "AEINRS_" {
+		t.Errorf("alice should move with the first rack, %s has %v", game.CurrentPlayer().Name, game.CurrentPlayer().tiles)
+	}
+	if n := len(game.players[1].tiles); n != HandSize {
+		t.Errorf("bob should be dealt a rack from the bag, has %v tiles", n)
+	}
+	if n := len(game.Tiles.Remaining); n != 100-3-7-7-3 {
+		t.Errorf("bag holds %v tiles", n)
+	}
+
+	// the four passes end with the player before alice
+	var passed []string
+	for _, turn := range game.Turns {
+		passed = append(passed, turn.player.Name)
+	}
+	if want := []string{"carol", "alice", "bob", "carol"}; !reflect.DeepEqual(passed, want) {
+		t.Errorf("passes taken by %v, want %v", passed, want)
+	}
+	if game.ScorelessTurns() != 4 || game.Turn.number != 5 {
+		t.Errorf("scoreless turns %v, turn %v", game.ScorelessTurns(), game.Turn.number)
+	}
+
+	position := game.CGP()
+	if position.Zeros != 4 || !reflect.DeepEqual(position.Scores, []int{10, 20, 30}) {
+		t.Errorf("position of the game: %s", position)
+	}
+	if _, err := game.ApplyTurn("swap", nil); err != nil {
+		t.Fatal(err)
+	}
+	if game.CurrentPlayer().Name != "bob" || game.CGP().Zeros != 5 {
+		t.Errorf("after a pass %s moves with %v scoreless turns", game.CurrentPlayer().Name, game.CGP().Zeros)
+	}
+}
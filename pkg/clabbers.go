@@ -0,0 +1,217 @@
+package scrabble
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// anagramIndex looks up words by their letters in any order, for the clabbers variant
+// where a word is valid when any anagram of it is in the dictionary
+// @keys the letters of every word in alphabetical order, built on first lookup
+// @sorted the keys as a trie of numbered letters, built on first move generation
+// @lex the trie walked by the move generator, a node stands for the letters played so far in any
+// order and is expanded on first use with every letter that keeps an anagram of some word within reach
+type anagramIndex struct {
+	keysOnce sync.Once
+	keys     map[string]bool
+	lexOnce  sync.Once
+	sorted   *keyNode
+	lex      *lexicon
+	mu       sync.Mutex
+	nodes    map[string]*trieNode
+	counts   map[*trieNode][]uint8
+}
+
+// keyNode is a node in the trie of words with their letters sorted
+// @most the highest count of each letter in the rest of any key below the node
+type keyNode struct {
+	terminal bool
+	edges    []keyEdge
+	most     []uint8
+}
+
+type keyEdge struct {
+	letter int
+	node   *keyNode
+}
+
+// anagramKey returns the letters of a word in alphabetical order, shared by every anagram of the word
+func anagramKey(letters []string) string {
+	sorted := append([]string(nil), letters...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, " ")
+}
+
+// HasAnagram checks whether the letters of a row of tiles are an anagram of a word
+// letters are tiles of the dictionary's tile set, with spanish tiles C and H never stand in for CH
+func (d Dictionary) HasAnagram(letters []string) bool {
+	index := d.anagramIndex()
+	index.keysOnce.Do(func() {
+		ts := d.TileSet()
+		index.keys = make(map[string]bool)
+		for word := range d.Words {
+			index.keys[anagramKey(ts.Split(word))] = true
+		}
+	})
+	return index.keys[anagramKey(letters)]
+}
+
+// anagramIndex returns the anagram index of the dictionary
+func (d Dictionary) anagramIndex() *anagramIndex {
+	if d.anagrams == nil {
+		// dictionaries built by hand are indexed on every call, use NewDictionary to share one
+		return &anagramIndex{}
+	}
+	return d.anagrams
+}
+
+// anagramLexicon returns the lexicon of the clabbers variant, sharing the alphabet of the dictionary
+func (d Dictionary) anagramLexicon() *lexicon {
+	index := d.anagramIndex()
+	index.lexOnce.Do(func() {
+		base := d.index()
+		index.sorted = &keyNode{}
+		for word := range d.Words {
+			letters := base.encode(word)
+			sort.Ints(letters)
+			index.sorted.insert(letters)
+		}
+		index.sorted.countMost(len(base.alphabet))
+
+		index.nodes = make(map[string]*trieNode)
+		index.counts = make(map[*trieNode][]uint8)
+		index.lex = &lexicon{
+			alphabet: base.alphabet,
+			letters:  base.letters,
+			tiles:    base.tiles,
+			multi:    base.multi,
+			anagrams: index,
+		}
+		index.lex.root = index.node(make([]uint8, len(base.alphabet)))
+	})
+	return index.lex
+}
+
+func (n *keyNode) insert(letters []int) {
+	node := n
+	for _, l := range letters {
+		var next *keyNode
+		for _, e := range node.edges {
+			if e.letter == l {
+				next = e.node
+				break
+			}
+		}
+		if next == nil {
+			next = &keyNode{}
+			node.edges = append(node.edges, keyEdge{letter: l, node: next})
+		}
+		node = next
+	}
+	node.terminal = true
+}
+
+// countMost fills in the highest letter counts below every node and sorts the edges
+func (n *keyNode) countMost(size int) {
+	sort.Slice(n.edges, func(i, j int) bool {
+		return n.edges[i].letter < n.edges[j].letter
+	})
+	n.most = make([]uint8, size)
+	for _, e := range n.edges {
+		e.node.countMost(size)
+		for l, count := range e.node.most {
+			if l == e.letter {
+				count++
+			}
+			if count > n.most[l] {
+				n.most[l] = count
+			}
+		}
+	}
+}
+
+// node returns the node of the lazy trie reached by playing the counted letters
+func (index *anagramIndex) node(counts []uint8) *trieNode {
+	key := string(counts)
+	if n, ok := index.nodes[key]; ok {
+		return n
+	}
+	n := &trieNode{terminal: index.isKey(counts)}
+	index.nodes[key] = n
+	index.counts[n] = counts
+	return n
+}
+
+// expand adds an edge for every letter that can follow the letters of the node, once
+func (index *anagramIndex) expand(n *trieNode) {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	counts, ok := index.counts[n]
+	if !ok {
+		return
+	}
+	delete(index.counts, n)
+	for l := range counts {
+		next := append([]uint8(nil), counts...)
+		next[l]++
+		if index.within(index.sorted, next, 0) {
+			n.edges = append(n.edges, trieEdge{letter: l, node: index.node(next)})
+		}
+	}
+}
+
+// isKey checks the counted letters are exactly the letters of a word
+func (index *anagramIndex) isKey(counts []uint8) bool {
+	node := index.sorted
+	for l, count := range counts {
+		for i := uint8(0); i < count; i++ {
+			var next *keyNode
+			for _, e := range node.edges {
+				if e.letter == l {
+					next = e.node
+					break
+				}
+			}
+			if next == nil {
+				return false
+			}
+			node = next
+		}
+	}
+	return node.terminal
+}
+
+// within checks some key below the node holds every counted letter, from the letter given onwards
+// keys are sorted so a letter skipped on the way down can never be found further below
+func (index *anagramIndex) within(node *keyNode, need []uint8, from int) bool {
+	for from < len(need) && need[from] == 0 {
+		from++
+	}
+	if from == len(need) {
+		return true
+	}
+	for l := from; l < len(need); l++ {
+		if need[l] > node.most[l] {
+			return false
+		}
+	}
+	for _, e := range node.edges {
+		switch {
+		case e.letter > from:
+			return false
+		case e.letter == from:
+			need[from]--
+			found := index.within(e.node, need, from)
+			need[from]++
+			if found {
+				return true
+			}
+		default:
+			if index.within(e.node, need, from) {
+				return true
+			}
+		}
+	}
+	return false
+}
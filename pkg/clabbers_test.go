@@ -0,0 +1,67 @@
+package scrabble
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestHasAnagram(t *testing.T) {
+	dict := NewDictionary([]string{"CAT", "DOGS"})
+	tests := []struct {
+		letters []string
+		want    bool
+	}{
+		{[]string{"C", "A", "T"}, true},
+		{[]string{"T", "C", "A"}, true},
+		{[]string{"S", "G", "O", "D"}, true},
+		{[]string{"C", "A"}, false},
+		{[]string{"C", "A", "T", "T"}, false},
+		{[]string{"D", "O", "G"}, false},
+	}
+	for _, tt := range tests {
+		if got := dict.HasAnagram(tt.letters); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.letters, got, tt.want)
+		}
+	}
+
+	spanish, err := LoadTileSetDictionary(filepath.Join("..", SpanishTiles.Dictionary), SpanishTiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !spanish.HasAnagram([]string{"O", "CH", "I", "C"}) || spanish.HasAnagram([]string{"C", "H", "I", "C", "O"}) {
+		t.Error("a CH tile should stand for CH, never the C and H tiles")
+	}
+}
+
+func TestClabbersPlays(t *testing.T) {
+	game := newTestGame(t, "CATSDOGEEIRNTS", "CAT")
+	if _, err := game.ApplyTurn("h8 TCA", nil); err == nil {
+		t.Fatal("TCA should not be a word under the casual rules")
+	}
+	game.rules = ClabbersRules
+	result, err := game.ApplyTurn("h8 TCA", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Score != 10 {
+		t.Errorf("TCA scored %v, want 10", result.Score)
+	}
+
+	// every order of the letters of CAT is generated, nothing else
+	rack := EnglishTiles.parseTiles(EnglishTiles.Split("CATQQVV"))
+	dict := NewDictionary([]string{"CAT"})
+	found := make(map[string]bool)
+	for _, m := range GenerateMovesWithRules(StandardLayout.NewBoard(), rack, dict, ClabbersRules) {
+		found[m.Word] = true
+	}
+	var words []string
+	for w := range found {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+	if want := []string{"ACT", "ATC", "CAT", "CTA", "TAC", "TCA"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("generated %v, want %v", words, want)
+	}
+}
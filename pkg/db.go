@@ -3,13 +3,15 @@ package scrabble
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
 // TODO(s):
 // - Add transactions for db layer
-// - Implement logic to insert results into historical table (upon finishing game)
 // - Add support for tracking word usages by player (words table)
 // - Add metadata to various tables
 // 	 - users: clarifying information/login information to enforce unique players
@@ -17,8 +19,10 @@ import (
 // - Implement queries to aggregate data about individual players (max scores/highest word/etc)
 
 // GameDB represents the internal scrabble state as a db schema
+// @dataDir directory the word lists of games read back are found in, the working directory when empty
 type GameDB struct {
-	db *sql.DB
+	db      *sql.DB
+	dataDir string
 }
 
 // users: users of the scrabble game
@@ -32,7 +36,12 @@ const createUsersTable = `CREATE TABLE if not exists users(
 const createGameTable = `CREATE TABLE if not exists games(
 	id INTEGER PRIMARY KEY,
 	board BLOB,
-	tiles BLOB
+	tiles BLOB,
+	seed INTEGER,
+	commitment TEXT,
+	salt TEXT,
+	finished BOOLEAN,
+	rules TEXT
 )`
 
 // player_states: tracks the score and tiles for a given player in a game
@@ -43,6 +52,9 @@ const createPlayerStatesTable = `CREATE TABLE if not exists player_states(
 	next INTEGER,
 	score INTEGER,
 	tiles BLOB,
+	bot TEXT,
+	engine TEXT,
+	team TEXT,
 	FOREIGN KEY(player_id) REFERENCES users(id),
 	FOREIGN KEY(next) REFERENCES player_states(id),
 	FOREIGN KEY(game_id) REFERENCES games(id)
@@ -61,12 +73,17 @@ const createTurnsTable = `CREATE TABLE if not exists turns(
 )`
 
 // historical: the historical game data for a given player (links to games played)
+// in a team game won and tied are the outcome of the team, team_score the score the partners share
+// a game won by nobody, on a shared best score or when nobody scored, is not counted as won by anyone
 const createHistoricalTable = `CREATE TABLE if not exists historical(
 	id INTEGER PRIMARY KEY,
 	score INTEGER,
 	max_single INTEGER,
 	max_word TEXT,
 	won  BOOLEAN,
+	tied BOOLEAN,
+	team TEXT,
+	team_score INTEGER,
 	gp_id INTEGER,
 	FOREIGN KEY(gp_id) REFERENCES player_states(id)
 )`
@@ -82,6 +99,60 @@ const createWordsTable = `CREATE TABLE if not exists words_played(
 	FOREIGN KEY(userID) REFERENCES users(id)
 )`
 
+// puzzles: positions of finished games where a player missed a big play
+const createPuzzlesTable = `CREATE TABLE if not exists puzzles(
+	id INTEGER PRIMARY KEY,
+	game_id INTEGER,
+	turn INTEGER,
+	player TEXT,
+	board BLOB,
+	rack BLOB,
+	rules TEXT,
+	answer TEXT,
+	missed TEXT,
+	UNIQUE(game_id, turn),
+	FOREIGN KEY(game_id) REFERENCES games(id)
+)`
+
+// daily_puzzles: the puzzle of each day, assigned the first time it is asked for and never changed
+// day is the date as YYYY-MM-DD
+const createDailyPuzzlesTable = `CREATE TABLE if not exists daily_puzzles(
+	day TEXT PRIMARY KEY,
+	puzzle_id INTEGER,
+	FOREIGN KEY(puzzle_id) REFERENCES puzzles(id)
+)`
+
+// puzzle_attempts: the answers users gave to puzzles
+const createPuzzleAttemptsTable = `CREATE TABLE if not exists puzzle_attempts(
+	id INTEGER PRIMARY KEY,
+	puzzle_id INTEGER,
+	user_id INTEGER,
+	input TEXT,
+	play TEXT,
+	score INTEGER,
+	solved BOOLEAN,
+	attempted_at TIMESTAMP,
+	FOREIGN KEY(puzzle_id) REFERENCES puzzles(id),
+	FOREIGN KEY(user_id) REFERENCES users(id)
+)`
+
+// study_cards: the spaced repetition schedule of every question a user has studied
+// lexicon is the name of the tile set whose dictionary the question comes from
+const createStudyCardsTable = `CREATE TABLE if not exists study_cards(
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER,
+	lexicon TEXT,
+	alphagram TEXT,
+	ease REAL,
+	interval INTEGER,
+	repetitions INTEGER,
+	due TIMESTAMP,
+	attempts INTEGER,
+	correct INTEGER,
+	UNIQUE(user_id, lexicon, alphagram),
+	FOREIGN KEY(user_id) REFERENCES users(id)
+)`
+
 // NewDB instantiates a gameDB
 func NewDB(db *sql.DB) *GameDB {
 	return &GameDB{
@@ -136,15 +207,95 @@ func (db *GameDB) InitDB() error {
 		return err
 	}
 
+	// create puzzle and study tables
+	for _, create := range []string{createPuzzlesTable, createDailyPuzzlesTable, createPuzzleAttemptsTable, createStudyCardsTable} {
+		_, err = db.db.Exec(create)
+		if err != nil {
+			return err
+		}
+	}
+
+	// bring tables created by older versions up to date
+	err = db.addColumnIfMissing("games", "seed", "INTEGER")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("games", "commitment", "TEXT")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("games", "salt", "TEXT")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("games", "finished", "BOOLEAN")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("games", "rules", "TEXT")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("player_states", "bot", "TEXT")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("player_states", "engine", "TEXT")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("player_states", "team", "TEXT")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("historical", "tied", "BOOLEAN")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("historical", "team", "TEXT")
+	if err != nil {
+		return err
+	}
+	err = db.addColumnIfMissing("historical", "team_score", "INTEGER")
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// addColumnIfMissing alters a pre-existing table to include a newly added column
+func (db *GameDB) addColumnIfMissing(table, column, definition string) error {
+	rows, err := db.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		err = rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk)
+		if err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	rows.Close()
+
+	_, err = db.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
 // GetGameByID joins all of the fields to instantiate a game state
 // joins data from games, turns, users and player_states tables
 func (db *GameDB) GetGameByID(id int) (*Game, error) {
 	// get the board, and current tiles
 	query := `
-	SELECT id, board, tiles FROM games WHERE id = ?`
+	SELECT id, board, tiles, seed, commitment, salt, finished, rules FROM games WHERE id = ?`
 	statement, err := db.db.Prepare(query)
 	if err != nil {
 		return nil, err
@@ -156,10 +307,20 @@ func (db *GameDB) GetGameByID(id int) (*Game, error) {
 
 	var boardBytes []byte
 	var tileBytes []byte
+	var seed sql.NullInt64
+	var commitment, salt, rules sql.NullString
+	var finished sql.NullBool
 
 	var game Game
 	for rows.Next() {
-		rows.Scan(&game.id, &boardBytes, &tileBytes)
+		rows.Scan(&game.id, &boardBytes, &tileBytes, &seed, &commitment, &salt, &finished, &rules)
+	}
+	game.commitment = commitment.String
+	game.salt = salt.String
+	game.finished = finished.Bool
+	game.rules, err = parseRuleSet(rules.String)
+	if err != nil {
+		return nil, err
 	}
 	err = json.Unmarshal(boardBytes, &game.board)
 	if err != nil {
@@ -170,14 +331,18 @@ func (db *GameDB) GetGameByID(id int) (*Game, error) {
 	if err != nil {
 		return nil, err
 	}
+	if seed.Valid {
+		game.Tiles.seed = seed.Int64
+	}
 
 	// Retrieves all relevent player information
 	// name, score, tiles, next player
 	// join tables linking user_id to player_states.player_id
 	playersQuery := `
-		SELECT users.id, player_states.id, users.name, users.use_plaintext, player_states.score, player_states.tiles, player_states.next
+		SELECT users.id, player_states.id, users.name, users.use_plaintext, player_states.score, player_states.tiles, player_states.next, player_states.bot, player_states.engine, player_states.team
 		FROM users JOIN player_states ON users.id = player_states.player_id
-		WHERE player_states.game_id = ?`
+		WHERE player_states.game_id = ?
+		ORDER BY player_states.id`
 	statement, err = db.db.Prepare(playersQuery)
 	if err != nil {
 		return nil, err
@@ -190,8 +355,12 @@ func (db *GameDB) GetGameByID(id int) (*Game, error) {
 	for rows.Next() {
 		var player Player
 		var tileBytes []byte
+		var bot, engine, team sql.NullString
 
-		rows.Scan(&player.id, &player.pStateID, &player.Name, &player.UsePlainText, &player.score, &tileBytes, &player.nextID)
+		rows.Scan(&player.id, &player.pStateID, &player.Name, &player.UsePlainText, &player.score, &tileBytes, &player.nextID, &bot, &engine, &team)
+		player.Bot = BotLevel(bot.String)
+		player.Engine = engine.String
+		player.Team = team.String
 		err = json.Unmarshal(tileBytes, &player.tiles)
 		if err != nil {
 			return nil, err
@@ -201,8 +370,8 @@ func (db *GameDB) GetGameByID(id int) (*Game, error) {
 
 	game.players = players
 
-	// Load in dictionary from disk
-	dict, err := LoadDictionary(dictPath)
+	// Load in dictionary of the tile set from disk
+	dict, err := db.loadDictionary(game.TileSet())
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +381,8 @@ func (db *GameDB) GetGameByID(id int) (*Game, error) {
 	turnsQuery := `
 	SELECT number, input, turns.score, next_player
 	FROM turns JOIN player_states ON turns.gp_id = player_states.id
-	WHERE player_states.game_id = ?`
+	WHERE player_states.game_id = ?
+	ORDER BY number`
 	statement, err = db.db.Prepare(turnsQuery)
 	if err != nil {
 		return nil, err
@@ -261,12 +431,19 @@ func (db *GameDB) GetGameByID(id int) (*Game, error) {
 // UpsertGame updates a game if it exists, creates a new one if not
 func (db *GameDB) UpsertGame(game *Game) error {
 	if game.id != 0 {
+		for _, p := range game.players {
+			err := db.updatePlayerState(game, p)
+			if err != nil {
+				return err
+			}
+		}
 		err := db.updateGame(game)
-		if err != nil {
+		if err != nil || !game.finished {
 			return err
 		}
+		return db.insertResults(game)
 	}
-	gameQuery := `INSERT INTO games (board, tiles) VALUES(?, ?)`
+	gameQuery := `INSERT INTO games (board, tiles, seed, commitment, salt, finished, rules) VALUES(?, ?, ?, ?, ?, ?, ?)`
 	boardJSON, err := json.Marshal(game.board)
 	if err != nil {
 		return err
@@ -277,8 +454,13 @@ func (db *GameDB) UpsertGame(game *Game) error {
 		return err
 	}
 
+	rulesJSON, err := json.Marshal(game.Rules())
+	if err != nil {
+		return err
+	}
+
 	statement, _ := db.db.Prepare(gameQuery)
-	result, err := statement.Exec(boardJSON, tilesJSON)
+	result, err := statement.Exec(boardJSON, tilesJSON, game.seed(), game.commitment, game.salt, game.finished, string(rulesJSON))
 	if err != nil {
 		return err
 	}
@@ -300,7 +482,7 @@ func (db *GameDB) UpsertGame(game *Game) error {
 }
 
 func (db *GameDB) insertPlayerState(game *Game) error {
-	playerStateQuery := `INSERT INTO player_states (game_id, player_id, next, score, tiles) VALUES (?, ?, ?, ?, ?)`
+	playerStateQuery := `INSERT INTO player_states (game_id, player_id, next, score, tiles, bot, engine, team) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 	statement, err := db.db.Prepare(playerStateQuery)
 	if err != nil {
 		return err
@@ -311,7 +493,7 @@ func (db *GameDB) insertPlayerState(game *Game) error {
 			return err
 		}
 
-		result, err := statement.Exec(game.id, p.id, p.nextID, p.score, tilesJSON)
+		result, err := statement.Exec(game.id, p.id, p.nextID, p.score, tilesJSON, string(p.Bot), p.Engine, p.Team)
 		if err != nil {
 			return err
 		}
@@ -393,10 +575,10 @@ func (db *GameDB) updateGame(game *Game) error {
 
 	insertQuery := `
 	UPDATE games 
-	SET board = ?, tiles = ?
+	SET board = ?, tiles = ?, finished = ?
 	WHERE id = ?`
 	statement, _ := db.db.Prepare(insertQuery)
-	result, err := statement.Exec(boardJSON, tilesJSON, game.id)
+	result, err := statement.Exec(boardJSON, tilesJSON, game.finished, game.id)
 	if err != nil {
 		return err
 	}
@@ -410,6 +592,36 @@ func (db *GameDB) updateGame(game *Game) error {
 	return nil
 }
 
+// insertResults records the outcome of a finished game for every player once
+// each player keeps their own score and best play, partners are credited with the result of their team
+func (db *GameDB) insertResults(game *Game) error {
+	var count int
+	err := db.db.QueryRow(`
+	SELECT COUNT(*) FROM historical h
+	JOIN player_states ps ON h.gp_id = ps.id
+	WHERE ps.game_id = ?`, game.id).Scan(&count)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	teamScores := make(map[string]int)
+	for _, t := range game.Teams() {
+		teamScores[t.Name] = t.Score
+	}
+	insertQuery := `INSERT INTO historical (score, max_single, max_word, won, tied, team, team_score, gp_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	statement, err := db.db.Prepare(insertQuery)
+	if err != nil {
+		return err
+	}
+	for _, p := range game.players {
+		_, err = statement.Exec(p.score, p.highestScore, p.highestWord, game.Won(p), game.Tied(p), p.Team, teamScores[p.Team], p.pStateID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (db *GameDB) updatePlayerState(game *Game, player Player) error {
 
 	updateQuery := `
@@ -484,3 +696,247 @@ func (db *GameDB) getUserByName(name string) (*Player, error) {
 
 	return &player, nil
 }
+
+// FinishedGameIDs lists the games that have been played to the end
+func (db *GameDB) FinishedGameIDs() ([]int, error) {
+	rows, err := db.db.Query(`SELECT id FROM games WHERE finished ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []int
+	for rows.Next() {
+		var id int
+		rows.Scan(&id)
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// InsertPuzzles stores new puzzles, a turn of a game already made into a puzzle is skipped
+// returns the number of puzzles added
+func (db *GameDB) InsertPuzzles(puzzles []Puzzle) (int, error) {
+	statement, err := db.db.Prepare(`
+	INSERT OR IGNORE INTO puzzles (game_id, turn, player, board, rack, rules, answer, missed)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	var added int
+	for _, p := range puzzles {
+		var fields [5][]byte
+		for i, v := range []interface{}{p.Board, p.Rack, p.Rules, p.Answer, p.Missed} {
+			fields[i], err = json.Marshal(v)
+			if err != nil {
+				return added, err
+			}
+		}
+		result, err := statement.Exec(p.GameID, p.Turn, p.Player, fields[0], fields[1], string(fields[2]), string(fields[3]), string(fields[4]))
+		if err != nil {
+			return added, err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return added, err
+		}
+		added += int(rows)
+	}
+	return added, nil
+}
+
+// puzzleColumns are read by scanPuzzle in order
+const puzzleColumns = `id, game_id, turn, player, board, rack, rules, answer, missed`
+
+// GetPuzzle retrieves a puzzle with the dictionary of its tile set loaded
+func (db *GameDB) GetPuzzle(id int64) (*Puzzle, error) {
+	rows, err := db.db.Query(`SELECT `+puzzleColumns+` FROM puzzles WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, ErrPuzzleNotFound
+	}
+	return db.scanPuzzle(rows)
+}
+
+// DailyPuzzle retrieves the puzzle of the day, every user is given the same puzzle on a given date
+// the puzzle is picked the first time the day is asked for and kept, puzzles generated later never change it
+// puzzles not yet given on another day are picked first
+func (db *GameDB) DailyPuzzle(day time.Time) (*Puzzle, error) {
+	date := day.Format("2006-01-02")
+	var id int64
+	err := db.db.QueryRow(`SELECT puzzle_id FROM daily_puzzles WHERE day = ?`, date).Scan(&id)
+	if err == nil {
+		return db.GetPuzzle(id)
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	unused := `FROM puzzles WHERE id NOT IN (SELECT puzzle_id FROM daily_puzzles)`
+	var count int
+	err = db.db.QueryRow(`SELECT COUNT(*) ` + unused).Scan(&count)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		unused = `FROM puzzles`
+		err = db.db.QueryRow(`SELECT COUNT(*) ` + unused).Scan(&count)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if count == 0 {
+		return nil, ErrPuzzleNotFound
+	}
+	err = db.db.QueryRow(`SELECT id `+unused+` ORDER BY id LIMIT 1 OFFSET ?`, dailyIndex(day, count)).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	// a day assigned meanwhile by another run is kept
+	_, err = db.db.Exec(`INSERT OR IGNORE INTO daily_puzzles (day, puzzle_id) VALUES (?, ?)`, date, id)
+	if err != nil {
+		return nil, err
+	}
+	err = db.db.QueryRow(`SELECT puzzle_id FROM daily_puzzles WHERE day = ?`, date).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	return db.GetPuzzle(id)
+}
+
+func (db *GameDB) scanPuzzle(rows *sql.Rows) (*Puzzle, error) {
+	var p Puzzle
+	var board, rack []byte
+	var rules, answer, missed string
+	err := rows.Scan(&p.ID, &p.GameID, &p.Turn, &p.Player, &board, &rack, &rules, &answer, &missed)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(board, &p.Board)
+	if err != nil {
+		return nil, err
+	}
+	p.Board.setCoordinates()
+	err = json.Unmarshal(rack, &p.Rack)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(answer), &p.Answer)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(missed), &p.Missed)
+	if err != nil {
+		return nil, err
+	}
+	p.Rules, err = parseRuleSet(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, _, err := p.Rules.TileSetLayout()
+	if err != nil {
+		return nil, err
+	}
+	p.dictionary, err = db.loadDictionary(ts)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// loadDictionary reads the word list of a tile set for a game or puzzle read back from the database
+// without a database the word list is found from the working directory
+func (db *GameDB) loadDictionary(ts TileSet) (Dictionary, error) {
+	path := ts.Dictionary
+	if db != nil && db.dataDir != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(db.dataDir, path)
+	}
+	return LoadTileSetDictionary(path, ts)
+}
+
+// InsertPuzzleAttempt records the answer of a user, each user has a single attempt at a puzzle
+func (db *GameDB) InsertPuzzleAttempt(name string, attempt PuzzleAttempt) error {
+	attempted, err := db.PuzzleAttempted(name, attempt.PuzzleID)
+	if err != nil {
+		return err
+	}
+	if attempted {
+		return ErrPuzzleAttempted
+	}
+	user := Player{Name: name}
+	err = db.InsertPlayer(&user)
+	if err != nil {
+		return err
+	}
+	_, err = db.db.Exec(`
+	INSERT INTO puzzle_attempts (puzzle_id, user_id, input, play, score, solved, attempted_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		attempt.PuzzleID, user.id, attempt.Input, attempt.Play, attempt.Score, attempt.Solved, attempt.Time)
+	return err
+}
+
+// PuzzleAttempted checks whether a user has already answered a puzzle
+func (db *GameDB) PuzzleAttempted(name string, puzzleID int64) (bool, error) {
+	var count int
+	err := db.db.QueryRow(`
+	SELECT COUNT(*) FROM puzzle_attempts JOIN users ON users.id = puzzle_attempts.user_id
+	WHERE users.name = ? AND puzzle_attempts.puzzle_id = ?`, name, puzzleID).Scan(&count)
+	return count > 0, err
+}
+
+// PuzzleStats totals the attempts, success rate and streaks of a user
+func (db *GameDB) PuzzleStats(name string) (PuzzleStats, error) {
+	rows, err := db.db.Query(`
+	SELECT puzzle_id, input, play, score, solved, attempted_at
+	FROM puzzle_attempts JOIN users ON users.id = puzzle_attempts.user_id
+	WHERE users.name = ?
+	ORDER BY puzzle_attempts.id`, name)
+	if err != nil {
+		return PuzzleStats{}, err
+	}
+	defer rows.Close()
+	var attempts []PuzzleAttempt
+	for rows.Next() {
+		var a PuzzleAttempt
+		rows.Scan(&a.PuzzleID, &a.Input, &a.Play, &a.Score, &a.Solved, &a.Time)
+		attempts = append(attempts, a)
+	}
+	return puzzleStats(name, attempts), nil
+}
+
+// StudyCards retrieves the cards a user has studied from the dictionary of a tile set, keyed by alphagram
+func (db *GameDB) StudyCards(name, lexicon string) (map[string]StudyCard, error) {
+	rows, err := db.db.Query(`
+	SELECT alphagram, ease, interval, repetitions, due, attempts, correct
+	FROM study_cards JOIN users ON users.id = study_cards.user_id
+	WHERE users.name = ? AND study_cards.lexicon = ?`, name, lexicon)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cards := make(map[string]StudyCard)
+	for rows.Next() {
+		var c StudyCard
+		rows.Scan(&c.Alphagram, &c.Ease, &c.Interval, &c.Repetitions, &c.Due, &c.Attempts, &c.Correct)
+		cards[c.Alphagram] = c
+	}
+	return cards, nil
+}
+
+// SaveStudyCard stores the schedule of a card after it has been reviewed
+func (db *GameDB) SaveStudyCard(name, lexicon string, card StudyCard) error {
+	user := Player{Name: name}
+	err := db.InsertPlayer(&user)
+	if err != nil {
+		return err
+	}
+	_, err = db.db.Exec(`
+	INSERT OR REPLACE INTO study_cards (user_id, lexicon, alphagram, ease, interval, repetitions, due, attempts, correct)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		user.id, lexicon, card.Alphagram, card.Ease, card.Interval, card.Repetitions, card.Due, card.Attempts, card.Correct)
+	return err
+}
@@ -3,29 +3,92 @@ package scrabble
 import (
 	"bufio"
 	"os"
+	"strings"
 )
 
 // Dictionary represents the presence of a word in the scrabble dictionary
+// words are spelled with the letters of a tile set, english unless loaded with another
 type Dictionary struct {
-	Words map[string]bool
+	Words    map[string]bool
+	lex      *lexicon
+	anagrams *anagramIndex
+	tiles    *TileSet
+}
+
+// NewDictionary builds a game dictionary from a list of words
+func NewDictionary(words []string) Dictionary {
+	Dict := Dictionary{
+		Words:    make(map[string]bool),
+		lex:      &lexicon{},
+		anagrams: &anagramIndex{},
+	}
+	for _, w := range words {
+		Dict.Words[w] = true
+	}
+	return Dict
 }
 
 // LoadDictionary Opens the path to a line separated dictionary and builds a working
 // game dictionary
 func LoadDictionary(path string) (Dictionary, error) {
+	return LoadTileSetDictionary(path, EnglishTiles)
+}
+
+// LoadTileSetDictionary opens a line separated dictionary of words spelled with the tile set
+// words are upper cased so lists in any case can be used
+func LoadTileSetDictionary(path string, ts TileSet) (Dictionary, error) {
 	var Dict Dictionary
 	Dict.Words = make(map[string]bool)
+	Dict.lex = &lexicon{}
+	Dict.anagrams = &anagramIndex{}
+	Dict.tiles = &ts
 	file, err := os.Open(path)
 	if err != nil {
 		return Dict, err
 	}
+	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
 	scanner.Split(bufio.ScanLines)
 
 	for scanner.Scan() {
-		Dict.Words[scanner.Text()] = true
+		word := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+		if word != "" {
+			Dict.Words[word] = true
+		}
+	}
+
+	return Dict, scanner.Err()
+}
+
+// TileSet returns the tile set the words are spelled with
+func (d Dictionary) TileSet() TileSet {
+	if d.tiles == nil {
+		return EnglishTiles
+	}
+	return *d.tiles
+}
+
+// HasTiles checks whether the letters of a row of tiles spell a word
+// a word is only spelled one way, with spanish tiles C and H never stand in for CH
+func (d Dictionary) HasTiles(letters []string) bool {
+	word := strings.Join(letters, "")
+	if !d.Words[word] {
+		return false
+	}
+	spelled := d.TileSet().Split(word)
+	if len(spelled) != len(letters) {
+		return false
 	}
+	for i := range spelled {
+		if spelled[i] != letters[i] {
+			return false
+		}
+	}
+	return true
+}
 
-	return Dict, nil
+// DefaultDictionary loads the dictionary used by new games
+func DefaultDictionary() (Dictionary, error) {
+	return LoadDictionary(dictPath)
 }
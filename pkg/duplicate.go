@@ -0,0 +1,269 @@
+package scrabble
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// duplicateSeat names the single seat of the game underneath a duplicate game
+const duplicateSeat = "duplicate"
+
+// Duplicate represents a game of duplicate scrabble
+// every player is given the same rack on the same board each round and submits a play privately
+// each player scores their own play, then only the top play of the round is placed on the board
+// the game underneath holds the shared board, bag and rack under a single seat
+type Duplicate struct {
+	game    *Game
+	players []DuplicatePlayer
+	plays   map[string]DuplicatePlay
+	stuck   bool
+	Rounds  []DuplicateRound
+}
+
+// DuplicatePlayer represents a participant of a duplicate game
+// @Bot lets the computer submit the player's plays, empty for humans
+type DuplicatePlayer struct {
+	Name  string
+	Bot   BotLevel
+	score int
+}
+
+// Score returns the total of the player's plays so far
+func (p DuplicatePlayer) Score() int {
+	return p.score
+}
+
+// DuplicatePlay represents the play a player submitted for a round
+// @Play the words formed, empty for a pass
+// @Invalid words of a play scoring nothing under the withdraw rule
+type DuplicatePlay struct {
+	Player  string   `json:"player"`
+	Input   string   `json:"input"`
+	Play    string   `json:"play"`
+	Score   int      `json:"score"`
+	Invalid []string `json:"invalid,omitempty"`
+}
+
+func (p DuplicatePlay) String() string {
+	switch {
+	case len(p.Invalid) > 0:
+		return fmt.Sprintf("%s: phony %v, 0 points", p.Player, p.Invalid)
+	case p.Play == "":
+		return fmt.Sprintf("%s: pass", p.Player)
+	}
+	return fmt.Sprintf("%s: %s %v points", p.Player, p.Play, p.Score)
+}
+
+// DuplicateRound represents the plays of every player for a rack and the top play placed on the board
+// @Top the highest scoring play of the rack, empty when the rack had no play and was returned to the bag
+// @Plays in the order the players were listed
+type DuplicateRound struct {
+	Number int             `json:"number"`
+	Rack   string          `json:"rack"`
+	Top    PlayReport      `json:"top"`
+	Plays  []DuplicatePlay `json:"plays"`
+}
+
+func (r DuplicateRound) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Round %v [%s]\n", r.Number, r.Rack)
+	if r.Top.Input == "" {
+		fmt.Fprintf(&b, "   top: no play, the rack was returned to the bag\n")
+	} else {
+		fmt.Fprintf(&b, "   top: %s %v points\n", r.Top.Play, r.Top.Score)
+	}
+	for _, p := range r.Plays {
+		fmt.Fprintf(&b, "   %s", p)
+		if p.Score < r.Top.Score {
+			fmt.Fprintf(&b, " (%v)", p.Score-r.Top.Score)
+		}
+		fmt.Fprintln(&b)
+	}
+	return b.String()
+}
+
+// NewDuplicate begins a duplicate game for the players, in the order listed
+// the options pick the seed, rules, board and tiles as for any other game
+func NewDuplicate(playerReq []PlayerRequest, opts GameOptions) (*Duplicate, error) {
+	if len(playerReq) == 0 {
+		return nil, ErrDuplicatePlayers
+	}
+	d := Duplicate{plays: make(map[string]DuplicatePlay)}
+	for _, p := range playerReq {
+		if _, err := d.player(p.Name); err == nil {
+			return nil, ErrDuplicatePlayers
+		}
+		d.players = append(d.players, DuplicatePlayer{Name: p.Name, Bot: p.Bot})
+	}
+
+	opts.FixedOrder = true
+	d.game = NewGameWithOptions([]PlayerRequest{{Name: duplicateSeat}}, opts, nil)
+	return &d, nil
+}
+
+// Game returns the game holding the shared board and bag
+func (d *Duplicate) Game() *Game {
+	return d.game
+}
+
+// Players returns the participants in the order listed
+func (d *Duplicate) Players() []DuplicatePlayer {
+	return d.players
+}
+
+// Rack returns the rack every player plays this round
+func (d *Duplicate) Rack() []Tile {
+	return d.game.CurrentPlayer().Tiles()
+}
+
+// Round returns the number of the round being played
+func (d *Duplicate) Round() int {
+	return len(d.Rounds) + 1
+}
+
+// IsOver checks whether the rack has been played out or has no play left to make
+func (d *Duplicate) IsOver() bool {
+	return d.stuck || d.game.IsOver()
+}
+
+// TopScore totals the top plays of every round, the most any player could have scored
+func (d *Duplicate) TopScore() int {
+	var total int
+	for _, r := range d.Rounds {
+		total += r.Top.Score
+	}
+	return total
+}
+
+// Waiting lists the players who have not submitted a play this round
+func (d *Duplicate) Waiting() []string {
+	var names []string
+	for _, p := range d.players {
+		if _, ok := d.plays[p.Name]; !ok {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// Submit records the play of a player for this round, scored against the shared position
+// a play is any `place` input or notation, `pass` scores nothing
+// a play that cannot be made is refused so the player can try again, under the withdraw
+// rule a play forming invalid words is recorded instead and scores nothing
+func (d *Duplicate) Submit(name, input string) (DuplicatePlay, error) {
+	if d.IsOver() {
+		return DuplicatePlay{}, ErrGameOver
+	}
+	if _, err := d.player(name); err != nil {
+		return DuplicatePlay{}, err
+	}
+	if _, ok := d.plays[name]; ok {
+		return DuplicatePlay{}, ErrAlreadySubmitted
+	}
+
+	play := DuplicatePlay{Player: name, Input: strings.TrimSpace(input)}
+	if play.Input != "pass" {
+		result, err := d.game.Preview(play.Input)
+		invalid, phony := err.(ErrInvalidWords)
+		switch {
+		case phony && d.game.Rules().Challenge == ChallengeWithdraw:
+			play.Invalid = invalid.failedWords
+		case err != nil:
+			return DuplicatePlay{}, err
+		default:
+			var words []string
+			for _, w := range result.Words {
+				words = append(words, w.String())
+			}
+			play.Play = strings.Join(words, ",")
+			play.Score = result.Score
+		}
+	}
+	d.plays[name] = play
+	return play, nil
+}
+
+// SubmitBots submits a play for every computer player still waiting this round
+func (d *Duplicate) SubmitBots() error {
+	for _, p := range d.players {
+		if _, ok := d.plays[p.Name]; ok || p.Bot == "" {
+			continue
+		}
+		input := "pass"
+		if move, ok := NewBot(p.Bot).ChooseMove(d.game.Position()); ok {
+			input = move.Input()
+		}
+		_, err := d.Submit(p.Name, input)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EndRound scores the submitted plays once every player has submitted and places the top play
+// a rack without any play is returned to the bag for a new one, the game is over when the bag cannot take it
+func (d *Duplicate) EndRound() (DuplicateRound, error) {
+	if d.IsOver() {
+		return DuplicateRound{}, ErrGameOver
+	}
+	if waiting := d.Waiting(); len(waiting) > 0 {
+		return DuplicateRound{}, ErrRoundIncomplete{Waiting: waiting}
+	}
+
+	rack := d.Rack()
+	round := DuplicateRound{
+		Number: d.Round(),
+		Rack:   leaveKey(rack),
+	}
+	for i, p := range d.players {
+		play := d.plays[p.Name]
+		d.players[i].score += play.Score
+		round.Plays = append(round.Plays, play)
+	}
+
+	// plays scoring the same are told apart by their equity
+	moves := d.game.Position().Moves(DefaultLeaves)
+	sort.Sort(ByScore(moves))
+	input := "swap"
+	if len(moves) > 0 {
+		round.Top = moves[0].Report()
+		input = round.Top.Input
+	} else if d.game.Rules().canSwap(len(rack), len(d.game.Tiles.Remaining)) {
+		for _, t := range rack {
+			input += " " + t.Letter
+		}
+	} else {
+		d.stuck = true
+	}
+
+	if !d.stuck {
+		_, err := d.game.ApplyTurn(input, nil)
+		if err != nil {
+			return DuplicateRound{}, err
+		}
+	}
+	d.Rounds = append(d.Rounds, round)
+	d.plays = make(map[string]DuplicatePlay)
+	return round, nil
+}
+
+// Standings returns the players from the highest to the lowest score
+func (d *Duplicate) Standings() []DuplicatePlayer {
+	standings := append([]DuplicatePlayer(nil), d.players...)
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].score > standings[j].score
+	})
+	return standings
+}
+
+// player finds a participant by name
+func (d *Duplicate) player(name string) (DuplicatePlayer, error) {
+	for _, p := range d.players {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return DuplicatePlayer{}, ErrUnknownPlayer{Name: name}
+}
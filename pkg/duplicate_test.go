@@ -0,0 +1,110 @@
+package scrabble
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newTestDuplicate starts a duplicate game for alice, bob and carol drawing from the bag in the order given
+func newTestDuplicate(t *testing.T, rules RuleSet, bag string, words ...string) *Duplicate {
+	t.Helper()
+	tiles := EnglishTiles.parseTiles(EnglishTiles.Split(bag))
+	dict := NewDictionary(words)
+	d, err := NewDuplicate([]PlayerRequest{{Name: "alice"}, {Name: "bob"}, {Name: "carol"}},
+		GameOptions{Seed: 1, Bag: tiles, Dictionary: &dict, Rules: &rules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDuplicateScoring(t *testing.T) {
+	if _, err := NewDuplicate([]PlayerRequest{{Name: "alice"}, {Name: "alice"}}, GameOptions{}); err != ErrDuplicatePlayers {
+		t.Errorf("players sharing a name: got %v", err)
+	}
+
+	d := newTestDuplicate(t, CasualRules, "CATSDOGEEIRNTSAE", "CAT", "CATS", "DOG")
+	for _, tt := range []struct {
+		player, input string
+		score         int
+		err           error
+	}{
+		{"alice", "h8 CAT", 10, nil},
+		{"alice", "h8 CATS", 0, ErrAlreadySubmitted},
+		{"dave", "h8 CAT", 0, ErrUnknownPlayer{Name: "dave"}},
+		{"bob", "h1 CATS", 0, ErrInvalidStart},
+		{"bob", "h8 CATS", 12, nil},
+	} {
+		play, err := d.Submit(tt.player, tt.input)
+		if err != tt.err || play.Score != tt.score {
+			t.Errorf("%s %s: got %v points and %v, want %v points and %v", tt.player, tt.input, play.Score, err, tt.score, tt.err)
+		}
+	}
+	if _, err := d.Submit("bob", "h8 GOD"); err != ErrAlreadySubmitted {
+		t.Errorf("a second play from bob: got %v", err)
+	}
+	if _, err := d.EndRound(); !reflect.DeepEqual(err, ErrRoundIncomplete{Waiting: []string{"carol"}}) {
+		t.Errorf("ending the round before carol played: got %v", err)
+	}
+	if _, err := d.Submit("carol", "pass"); err != nil {
+		t.Fatal(err)
+	}
+
+	rack := leaveKey(d.Rack())
+	round, err := d.EndRound()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// everyone scores their own play, the board takes the top play of the rack
+	if round.Rack != rack || round.Top.Score != 12 || d.TopScore() != 12 {
+		t.Errorf("round of %s, top %v", round.Rack, round.Top)
+	}
+	var names []string
+	var scores []int
+	for _, p := range d.Standings() {
+		names = append(names, p.Name)
+		scores = append(scores, p.Score())
+	}
+	if !reflect.DeepEqual(names, []string{"bob", "alice", "carol"}) || !reflect.DeepEqual(scores, []int{12, 10, 0}) {
+		t.Errorf("standings %v with %v", names, scores)
+	}
+	if d.Round() != 2 || len(d.Waiting()) != 3 || len(d.Game().Turns) != 1 || d.Game().LastPlacements() == nil {
+		t.Errorf("round %v should begin with the top play on the board", d.Round())
+	}
+}
+
+func TestDuplicatePhonies(t *testing.T) {
+	casual := newTestDuplicate(t, CasualRules, "CATSDOGEEIRNTS", "CAT")
+	if _, err := casual.Submit("alice", "h8 GOD"); err == nil {
+		t.Error("a phony should be refused under the void rule")
+	}
+
+	classic := newTestDuplicate(t, ClassicRules, "CATSDOGEEIRNTS", "CAT")
+	play, err := classic.Submit("alice", "h8 GOD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if play.Score != 0 || !reflect.DeepEqual(play.Invalid, []string{"GOD"}) {
+		t.Errorf("a phony should be recorded for no points, got %+v", play)
+	}
+}
+
+func TestDuplicateEndsWithoutAPlay(t *testing.T) {
+	d := newTestDuplicate(t, CasualRules, "QQVVXXZ", "CAT")
+	for _, name := range []string{"alice", "bob", "carol"} {
+		if _, err := d.Submit(name, "pass"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	round, err := d.EndRound()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the empty bag cannot take the rack back
+	if round.Top.Input != "" || !d.IsOver() {
+		t.Errorf("top %v, over %v", round.Top, d.IsOver())
+	}
+	if _, err := d.Submit("alice", "pass"); err != ErrGameOver {
+		t.Errorf("a play after the end: got %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package scrabble
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Default budget of the endgame solver
+const (
+	defaultEndgameDepth = 14
+	defaultEndgameTime  = 10 * time.Second
+	endgameCheckNodes   = 64
+)
+
+// EndgameOptions controls the budget of the endgame solver
+// @MaxDepth maximum number of plays searched, 0 for the default
+// @Duration stops deepening once elapsed, 0 for the default
+type EndgameOptions struct {
+	MaxDepth int
+	Duration time.Duration
+}
+
+// EndgameResult represents the best sequence of plays found by the solver
+// @Moves alternating plays starting with the player to move, passes have no placements
+// @Spread points gained over the opponent by following the sequence, including the rack adjustment
+// @Final spread between the two players once the sequence has been played
+// @Complete indicates every line was searched to the end of the game, making the result exact
+type EndgameResult struct {
+	Moves    []Move
+	Spread   int
+	Final    int
+	Depth    int
+	Complete bool
+	Nodes    int
+}
+
+func (r EndgameResult) String() string {
+	var plays []string
+	for _, m := range r.Moves {
+		if len(m.Placements) == 0 {
+			plays = append(plays, "pass")
+			continue
+		}
+		plays = append(plays, fmt.Sprintf("%s %v", m.Notation(), m.Score))
+	}
+	exact := "best found"
+	if r.Complete {
+		exact = "exact"
+	}
+	return fmt.Sprintf("%s\nspread %+v, final spread %+v (%s, depth %v, %v nodes)",
+		strings.Join(plays, " -> "), r.Spread, r.Final, exact, r.Depth, r.Nodes)
+}
+
+// endgameSolver holds the state of a single endgame search
+// @moves caches generated moves by board and rack, deeper iterations revisit the same positions
+type endgameSolver struct {
+	pos      Position
+	deadline time.Time
+	nodes    int
+	timedOut bool
+	cutoff   bool
+	moves    map[string][]Move
+}
+
+// SolveEndgame finds the spread maximizing sequence of plays once the bag is empty
+// the opponent rack is the unseen tiles, searched with alpha-beta and iterative deepening
+func SolveEndgame(pos Position, opts EndgameOptions) (EndgameResult, error) {
+	if pos.BagSize != 0 {
+		return EndgameResult{}, ErrBagNotEmpty
+	}
+	if len(pos.Racks) != 2 {
+		return EndgameResult{}, ErrEndgamePlayers
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = defaultEndgameDepth
+	}
+	if opts.Duration <= 0 {
+		opts.Duration = defaultEndgameTime
+	}
+
+	solver := endgameSolver{
+		pos:      pos,
+		deadline: time.Now().Add(opts.Duration),
+		moves:    make(map[string][]Move),
+	}
+	racks := [2][]Tile{pos.Rack(), pos.Unseen}
+
+	var result EndgameResult
+	for depth := 1; depth <= opts.MaxDepth; depth++ {
+		solver.cutoff = false
+		value, line := solver.search(pos.Board, racks, depth, 0, -1<<30, 1<<30, result.Moves)
+		if solver.timedOut {
+			break
+		}
+		result = EndgameResult{
+			Moves:    line,
+			Spread:   value,
+			Depth:    depth,
+			Complete: !solver.cutoff,
+		}
+		if result.Complete {
+			break
+		}
+	}
+
+	opponent := 1 - pos.ToMove
+	result.Final = pos.Scores[pos.ToMove] - pos.Scores[opponent] + result.Spread
+	result.Nodes = solver.nodes
+	return result, nil
+}
+
+// search returns the best spread for racks[0] to move and the line achieving it
+// passes counts consecutive passes, a second pass ends the game
+// principal is the best line of the previous iteration, searched first
+func (s *endgameSolver) search(board Board, racks [2][]Tile, depth, passes, alpha, beta int, principal []Move) (int, []Move) {
+	s.nodes++
+	if s.nodes%endgameCheckNodes == 0 && time.Now().After(s.deadline) {
+		s.timedOut = true
+	}
+	if s.timedOut {
+		return 0, nil
+	}
+	if depth == 0 {
+		// unfinished line, assume each player is stuck with their rack
+		s.cutoff = true
+		return rackValue(racks[1]) - rackValue(racks[0]), nil
+	}
+
+	moves := s.generate(board, racks[0])
+	if len(principal) > 0 {
+		for i, m := range moves {
+			if sameMove(m, principal[0]) {
+				moves[0], moves[i] = moves[i], moves[0]
+				break
+			}
+		}
+		principal = principal[1:]
+	}
+
+	best := -1 << 30
+	var bestLine []Move
+	for i, m := range moves {
+		var value int
+		var line []Move
+		var next []Move
+		if i == 0 {
+			next = principal
+		}
+		switch {
+		case len(m.Placements) == 0 && passes > 0:
+			// both players passed, each loses the value of their rack
+			value = rackValue(racks[1]) - rackValue(racks[0])
+		case len(m.Placements) == 0:
+			value, line = s.search(board, [2][]Tile{racks[1], racks[0]}, depth-1, passes+1, -beta, -alpha, next)
+			value = -value
+		case len(m.Leave) == 0:
+			// going out collects the opponents rack, which they also lose
+			value = m.Score + 2*rackValue(racks[1])
+		case depth == 1:
+			// skip building the board for a reply that will not be searched
+			s.cutoff = true
+			value = m.Score - (rackValue(m.Leave) - rackValue(racks[1]))
+		default:
+			value, line = s.search(board.WithMove(m), [2][]Tile{racks[1], m.Leave}, depth-1, 0, m.Score-beta, m.Score-alpha, next)
+			value = m.Score - value
+		}
+		if s.timedOut {
+			return 0, nil
+		}
+
+		if value > best {
+			best = value
+			bestLine = append([]Move{m}, line...)
+		}
+		if best > alpha {
+			alpha = best
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best, bestLine
+}
+
+// generate returns the moves of the rack followed by a pass, best scores first
+func (s *endgameSolver) generate(board Board, rack []Tile) []Move {
+	// blanks are written in lower case as they score nothing in cross words, and every square and tile
+	// is followed by a comma so letters of several characters (CH) never run into the next one (C, H)
+	var key strings.Builder
+	for _, row := range board {
+		for _, sq := range row {
+			switch {
+			case sq.IsEmpty():
+				key.WriteByte('.')
+			case sq.Value.IsBlank:
+				key.WriteString(strings.ToLower(sq.Value.Letter))
+			default:
+				key.WriteString(sq.Value.Letter)
+			}
+			key.WriteByte(',')
+		}
+	}
+	letters := make([]string, len(rack))
+	for i, t := range rack {
+		letters[i] = tileKey(t)
+	}
+	sort.Strings(letters)
+	key.WriteString(strings.Join(letters, ","))
+
+	cached, ok := s.moves[key.String()]
+	if !ok {
+		cached = s.pos.generate(board, rack)
+		sort.Sort(ByScore(cached))
+		cached = append(cached, Move{Leave: rack})
+		s.moves[key.String()] = cached
+	}
+	// callers reorder moves, keep the cached order intact
+	return append([]Move(nil), cached...)
+}
+
+// sameMove compares the tiles and locations of two moves
+func sameMove(a, b Move) bool {
+	if len(a.Placements) != len(b.Placements) {
+		return false
+	}
+	for i := range a.Placements {
+		if a.Placements[i] != b.Placements[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package scrabble
+
+import (
+	"testing"
+	"time"
+)
+
+// endgamePosition sets up an empty bag position, the rack to move against the unseen tiles of the opponent
+func endgamePosition(t *testing.T, plays []string, rack, unseen string, words ...string) Position {
+	t.Helper()
+	board, err := SetupBoard(StandardLayout, EnglishTiles, nil, plays)
+	if err != nil {
+		t.Fatal(err)
+	}
+	racks := make([][]Tile, 2)
+	for i, letters := range []string{rack, unseen} {
+		racks[i], err = parseSetupTiles(EnglishTiles, letters)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return Position{
+		Board:      board,
+		Racks:      racks,
+		Scores:     []int{0, 0},
+		Unseen:     racks[1],
+		Dictionary: NewDictionary(words),
+		Rules:      CasualRules,
+	}
+}
+
+// referenceEndgame searches every line to the end of the game without pruning or caching
+func referenceEndgame(pos Position, board Board, racks [2][]Tile, passes int) int {
+	moves := append(pos.generate(board, racks[0]), Move{Leave: racks[0]})
+	best := -1 << 30
+	for _, m := range moves {
+		var value int
+		switch {
+		case len(m.Placements) == 0 && passes > 0:
+			value = rackValue(racks[1]) - rackValue(racks[0])
+		case len(m.Placements) == 0:
+			value = -referenceEndgame(pos, board, [2][]Tile{racks[1], racks[0]}, passes+1)
+		case len(m.Leave) == 0:
+			value = m.Score + 2*rackValue(racks[1])
+		default:
+			value = m.Score - referenceEndgame(pos, board.WithMove(m), [2][]Tile{racks[1], m.Leave}, 0)
+		}
+		if value > best {
+			best = value
+		}
+	}
+	return best
+}
+
+func solveTestEndgame(t *testing.T, pos Position) EndgameResult {
+	t.Helper()
+	result, err := SolveEndgame(pos, EndgameOptions{MaxDepth: 10, Duration: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Complete {
+		t.Fatalf("search did not reach the end of the game: %v", result)
+	}
+	return result
+}
+
+func TestEndgameGoingOut(t *testing.T) {
+	pos := endgamePosition(t, []string{"h7 CAT"}, "S", "Q", "CAT", "CATS")
+	result := solveTestEndgame(t, pos)
+	// CATS scores 6 and goes out, the stuck Q counts twice
+	if result.Spread != 26 || len(result.Moves) != 1 || result.Moves[0].Word != "CATS" {
+		t.Errorf("got %v, want CATS going out for a spread of 26", result)
+	}
+}
+
+func TestEndgameSetsUpALongerPlay(t *testing.T) {
+	pos := endgamePosition(t, []string{"h7 CAT"}, "SS", "Q", "CAT", "CATS", "SCAT", "SCATS")
+	result := solveTestEndgame(t, pos)
+	// SCATS at once scores 7 and goes out for 27, while CATS (or SCAT) for 6 followed by SCATS for 7
+	// after the stuck opponent passes gets 33
+	if result.Spread != 33 || len(result.Moves) != 3 || result.Moves[0].Score != 6 || len(result.Moves[1].Placements) != 0 {
+		t.Errorf("got %v, want a 6 point play, a pass and SCATS for a spread of 33", result)
+	}
+}
+
+func TestEndgameMatchesFullSearch(t *testing.T) {
+	words := []string{
+		"AH", "AS", "AT", "ATE", "EAT", "EATH", "EATS", "EE", "EH", "EHS", "ES", "ET", "ETA", "ETH",
+		"HA", "HAT", "HATE", "HE", "HEAT", "HES", "HET", "SAT", "SH", "TA", "TE", "TEA", "THE",
+	}
+	tests := []struct {
+		name         string
+		plays        []string
+		rack, unseen string
+	}{
+		{"letters", []string{"h8 AT"}, "EHT", "AEH"},
+		// the blank and the E can each be played as the E of HATE, the replies through it score differently
+		{"blank and letter", []string{"h7 HAT"}, "?SHE", "T"},
+		// lines placing the blank or an E on the same square must not share the replies found for the other
+		{"blank and letters", []string{"h8 AT"}, "?AEE", "ST"},
+	}
+	for _, tt := range tests {
+		pos := endgamePosition(t, tt.plays, tt.rack, tt.unseen, words...)
+		want := referenceEndgame(pos, pos.Board, [2][]Tile{pos.Racks[0], pos.Racks[1]}, 0)
+		result := solveTestEndgame(t, pos)
+		if result.Spread != want {
+			t.Errorf("%s: solver found %v, the full search %v", tt.name, result, want)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package scrabble
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Version of the external engine protocol, sent when an engine is started
+const engineProtocolVersion = 1
+
+// Limits of external engines
+// @defaultEngineTimeout time an engine has to become ready and to choose each move
+// @engineLineBuffer lines read ahead of the game, extra output never blocks the engine
+const (
+	defaultEngineTimeout = 10 * time.Second
+	engineLineBuffer     = 64
+)
+
+// Strategy decides the input for the current player of a game
+// implemented by the built in bots and by external engines
+type Strategy interface {
+	Turn(game *Game) string
+}
+
+// Engine is an external program playing a seat over stdin and stdout
+// the protocol is line based, see the README for the full description
+// @Name reported by the engine during the handshake, the command when not reported
+// @Timeout limit for the handshake and for each turn, the turn is passed when it is exceeded
+// @Log records every line sent and received when set
+type Engine struct {
+	Command string
+	Name    string
+	Timeout time.Duration
+	Log     *log.Logger
+
+	cmd   *exec.Cmd
+	in    io.WriteCloser
+	lines chan string
+}
+
+// StartEngine runs the command and waits for the engine to report it is ready
+func StartEngine(command string, timeout time.Duration, logger *log.Logger) (*Engine, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, ErrEngineCommand
+	}
+	if timeout <= 0 {
+		timeout = defaultEngineTimeout
+	}
+
+	e := &Engine{
+		Command: command,
+		Name:    command,
+		Timeout: timeout,
+		Log:     logger,
+		cmd:     exec.Command(args[0], args[1:]...),
+		lines:   make(chan string, engineLineBuffer),
+	}
+	var err error
+	e.in, err = e.cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := e.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	err = e.cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(out)
+		for scanner.Scan() {
+			e.lines <- strings.TrimSpace(scanner.Text())
+		}
+		close(e.lines)
+	}()
+
+	e.send(fmt.Sprintf("scrabble %v", engineProtocolVersion))
+	deadline := time.Now().Add(e.Timeout)
+	for {
+		line, err := e.receive(deadline)
+		if err != nil {
+			e.Close()
+			return nil, err
+		}
+		switch {
+		case line == "ready":
+			return e, nil
+		case strings.HasPrefix(line, "id name "):
+			e.Name = strings.TrimPrefix(line, "id name ")
+		}
+	}
+}
+
+// Turn sends the position of the current player and waits for the engine's move
+// an engine that times out, exits or replies with nonsense passes the turn
+// the whole turn shares one deadline so info lines never give the engine more time
+func (e *Engine) Turn(game *Game) string {
+	deadline := time.Now().Add(e.Timeout)
+	pos := game.Position()
+	e.drain()
+	e.send("position")
+	for x, row := range pos.Board.Rows() {
+		e.send(fmt.Sprintf("row %s %s", string(toRune(x+1)), row))
+	}
+	var scores []string
+	for _, s := range pos.Scores {
+		scores = append(scores, fmt.Sprint(s))
+	}
+	e.send(fmt.Sprintf("rack %s", tileLetters(pos.Rack())))
+	e.send(fmt.Sprintf("unseen %s", tileLetters(pos.Unseen)))
+	e.send(fmt.Sprintf("bag %v", pos.BagSize))
+	e.send(fmt.Sprintf("scores %s", strings.Join(scores, " ")))
+	e.send(fmt.Sprintf("tomove %v", pos.ToMove))
+	e.send(fmt.Sprintf("time %v", time.Until(deadline).Milliseconds()))
+	e.send("go")
+
+	for {
+		line, err := e.receive(deadline)
+		if err != nil {
+			e.logf("%s passes: %v", e.Name, err)
+			return "swap"
+		}
+		if !strings.HasPrefix(line, "move ") {
+			// anything else, including info lines, is only logged
+			continue
+		}
+		move := strings.TrimSpace(strings.TrimPrefix(line, "move "))
+		if move == "pass" {
+			return "swap"
+		}
+		return move
+	}
+}
+
+// Illegal tells the engine its last move was rejected
+func (e *Engine) Illegal(err error) {
+	e.send(fmt.Sprintf("illegal %v", err))
+}
+
+// Close asks the engine to quit and waits for it to exit
+func (e *Engine) Close() error {
+	e.send("quit")
+	e.in.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- e.cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(e.Timeout):
+		e.cmd.Process.Kill()
+		return ErrEngineTimeout
+	}
+}
+
+// send writes a line to the engine, a broken pipe shows up as the next receive failing
+func (e *Engine) send(line string) {
+	e.logf("%s < %s", e.Name, line)
+	fmt.Fprintln(e.in, line)
+}
+
+// drain discards lines left over from an earlier turn, such as a move sent after a timeout
+func (e *Engine) drain() {
+	for {
+		select {
+		case line, ok := <-e.lines:
+			if !ok {
+				return
+			}
+			e.logf("%s > %s (ignored)", e.Name, line)
+		default:
+			return
+		}
+	}
+}
+
+// receive waits for the next line from the engine until the deadline
+func (e *Engine) receive(deadline time.Time) (string, error) {
+	left := time.Until(deadline)
+	if left <= 0 {
+		return "", ErrEngineTimeout
+	}
+	timer := time.NewTimer(left)
+	defer timer.Stop()
+	select {
+	case line, ok := <-e.lines:
+		if !ok {
+			return "", ErrEngineExited
+		}
+		e.logf("%s > %s", e.Name, line)
+		return line, nil
+	case <-timer.C:
+		return "", ErrEngineTimeout
+	}
+}
+
+func (e *Engine) logf(format string, args ...interface{}) {
+	if e.Log != nil {
+		e.Log.Printf(format, args...)
+	}
+}
+
+// tileLetters formats tiles as a single word, blanks as `_` and letters of several characters bracketed
+func tileLetters(tiles []Tile) string {
+	var letters strings.Builder
+	for _, t := range tiles {
+		letters.WriteString(writtenLetter(tileKey(t)))
+	}
+	return letters.String()
+}
+
+// ComputerPlayers starts a strategy for every seat of the game not played by a human
+// engines are started from their commands, the returned seats are keyed by player name
+func (game *Game) ComputerPlayers(logger *log.Logger) (map[string]Strategy, error) {
+	seats := make(map[string]Strategy)
+	for _, p := range game.players {
+		switch {
+		case p.Engine != "":
+			engine, err := StartEngine(p.Engine, 0, logger)
+			if err != nil {
+				CloseComputerPlayers(seats)
+				return nil, err
+			}
+			seats[p.Name] = engine
+		case p.Bot != "":
+			seats[p.Name] = NewBot(p.Bot)
+		}
+	}
+	return seats, nil
+}
+
+// CloseComputerPlayers stops every external engine among the seats
+func CloseComputerPlayers(seats map[string]Strategy) {
+	for _, s := range seats {
+		if e, ok := s.(*Engine); ok {
+			e.Close()
+		}
+	}
+}
+
+// PlayComputerTurn asks the strategy for the current player's move and applies it
+// an illegal move from an engine is reported back to it and the turn is passed instead
+func (game *Game) PlayComputerTurn(s Strategy, gameDB *GameDB) (string, Result, error) {
+	input := s.Turn(game)
+	result, err := game.ApplyTurn(input, gameDB)
+	engine, ok := s.(*Engine)
+	if err == nil || !ok {
+		return input, result, err
+	}
+
+	engine.logf("%s played an illegal move %q: %v", engine.Name, input, err)
+	engine.Illegal(err)
+	result, err = game.ApplyTurn("swap", gameDB)
+	return "swap", result, err
+}
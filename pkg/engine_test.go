@@ -0,0 +1,129 @@
+package scrabble
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startScriptEngine runs a shell script as an external engine, the script can write files next to `$0`
+func startScriptEngine(t *testing.T, script string) (*Engine, string) {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell to run the engine")
+	}
+	path := filepath.Join(t.TempDir(), "engine.sh")
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := StartEngine("sh "+path, 5*time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return engine, path
+}
+
+// rackLetters lists the letters of a player's rack in order
+func rackLetters(p Player) string {
+	var letters strings.Builder
+	for _, tile := range p.tiles {
+		letters.WriteString(tile.Letter)
+	}
+	return letters.String()
+}
+
+func TestEngineHandshake(t *testing.T) {
+	engine, _ := startScriptEngine(t, `read version
+echo "id name tester"
+echo ready
+while read line; do
+	case "$line" in
+		go) echo "move h8 CAB" ;;
+		quit) exit 0 ;;
+	esac
+done
+`)
+	defer engine.Close()
+	if engine.Name != "tester" {
+		t.Errorf("name %q, want the one reported in the handshake", engine.Name)
+	}
+
+	game := newTestGame(t, "ABCDEFGHIJKLMN", "CAB")
+	input, result, err := game.PlayComputerTurn(engine, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input != "h8 CAB" || result.Score == 0 {
+		t.Errorf("played %q for %v, want the engine's move to score", input, result.Score)
+	}
+}
+
+func TestEngineIllegalSwap(t *testing.T) {
+	engine, path := startScriptEngine(t, `echo ready
+while read line; do
+	case "$line" in
+		go) echo "move swap a b z" ;;
+		illegal*) echo "$line" > "$0.illegal" ;;
+		quit) exit 0 ;;
+	esac
+done
+`)
+	game := newTestGame(t, "ABCDEFGHIJKLMNOPQRSTU")
+	input, _, err := game.PlayComputerTurn(engine, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input != "swap" {
+		t.Errorf("illegal swap replaced by %q, want a pass", input)
+	}
+	if rack := rackLetters(game.players[0]); rack != "ABCDEFG" {
+		t.Errorf("rack after a rejected swap is %s, want ABCDEFG", rack)
+	}
+	if len(game.Tiles.Remaining) != 7 {
+		t.Errorf("bag has %v tiles after a rejected swap, want 7", len(game.Tiles.Remaining))
+	}
+
+	if err := engine.Close(); err != nil {
+		t.Fatal(err)
+	}
+	told, err := os.ReadFile(path + ".illegal")
+	if err != nil || !strings.HasPrefix(string(told), "illegal ") {
+		t.Errorf("engine was not told its move was illegal: %q %v", told, err)
+	}
+}
+
+func TestEngineInfoDoesNotExtendTurn(t *testing.T) {
+	engine, path := startScriptEngine(t, `echo ready
+while read line; do
+	case "$line" in
+		time*) echo "$line" > "$0.time" ;;
+		go) while true; do echo "info thinking"; sleep 0.05; done ;;
+	esac
+done
+`)
+	defer engine.Close()
+	engine.Timeout = 500 * time.Millisecond
+
+	game := newTestGame(t, "ABCDEFGHIJKLMN")
+	start := time.Now()
+	input, _, err := game.PlayComputerTurn(engine, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input != "swap" {
+		t.Errorf("engine that never moves played %q, want a pass", input)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("turn took %v, info lines should not extend the deadline", elapsed)
+	}
+
+	told, err := os.ReadFile(path + ".time")
+	var left int
+	if _, scanErr := fmt.Sscanf(string(told), "time %d", &left); err != nil || scanErr != nil || left <= 0 || left > 500 {
+		t.Errorf("engine was told %q, want the time left in the turn", told)
+	}
+}
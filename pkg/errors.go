@@ -1,6 +1,9 @@
 package scrabble
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ErrNoValidWordsFound represents when a user places a single tile but it forms no words
 var ErrNoValidWordsFound = fmt.Errorf("no valid words found in tile placements")
@@ -18,7 +21,7 @@ var ErrWordDisconnected = fmt.Errorf("Word placement invalid, gap between letter
 var ErrInvalidPlacement = fmt.Errorf("Word placement invalid, must place only horizontal or vertically")
 
 // ErrInvalidSpace indicates an invalid tile placement
-var ErrInvalidSpace = fmt.Errorf("Provided space is illegal. Must be in range of [0,%v], [0,%v]", Size-1, Size-1)
+var ErrInvalidSpace = fmt.Errorf("Provided space is illegal. Must be on the board")
 
 // ErrInvalidStart starting turn requires tile be placed in center of board
 var ErrInvalidStart = fmt.Errorf("Starting move must touch center tile")
@@ -26,6 +29,63 @@ var ErrInvalidStart = fmt.Errorf("Starting move must touch center tile")
 // ErrInvalidAction is when a user attempts to perform an illegal operation
 var ErrInvalidAction = fmt.Errorf("Invalid action requested: allowed [swap, place]")
 
+// Errors related to verifying the bag commitment of a game
+var (
+	ErrGameInProgress     = fmt.Errorf("game has not finished, bag cannot be revealed")
+	ErrNoCommitment       = fmt.Errorf("game was created without a bag commitment")
+	ErrOrderedBag         = fmt.Errorf("game was played with a pre-ordered bag and cannot be verified")
+	ErrCommitmentMismatch = fmt.Errorf("revealed seed does not match the commitment")
+)
+
+// Errors related to solving endgames
+var (
+	ErrBagNotEmpty    = fmt.Errorf("endgame can only be solved once the bag is empty")
+	ErrEndgamePlayers = fmt.Errorf("endgame can only be solved for two players")
+)
+
+// Errors related to self play
+var (
+	ErrSelfPlayPlayers = fmt.Errorf("self play requires two players with distinct names")
+	ErrSelfPlayBot     = fmt.Errorf("every self play seat must be a computer player")
+)
+
+// Errors related to external engines
+var (
+	ErrEngineCommand = fmt.Errorf("engine command is empty")
+	ErrEngineTimeout = fmt.Errorf("engine did not reply in time")
+	ErrEngineExited  = fmt.Errorf("engine exited unexpectedly")
+)
+
+// ErrEngineNotAllowed represents an engine command a game asks for that was not allowed to run
+type ErrEngineNotAllowed struct {
+	Command string
+}
+
+func (e ErrEngineNotAllowed) Error() string {
+	return fmt.Sprintf("engine %q is not allowed to run, allow it with --allow-engine %q", e.Command, e.Command)
+}
+
+// ErrTeams is returned when players are not split into at least two teams of the same size
+var ErrTeams = fmt.Errorf("every player needs a team, with at least two teams of the same size")
+
+// Errors of duplicate games
+var (
+	ErrDuplicatePlayers = fmt.Errorf("duplicate games need at least one player, with distinct names")
+	ErrAlreadySubmitted = fmt.Errorf("a play was already submitted this round")
+)
+
+// Errors of puzzles
+var (
+	ErrPuzzleNotFound  = fmt.Errorf("no such puzzle, puzzles are generated from finished games")
+	ErrPuzzleAttempted = fmt.Errorf("puzzle was already attempted")
+)
+
+// ErrNoSuchTurn represents a turn number beyond the turns played in a game
+var ErrNoSuchTurn = fmt.Errorf("game has no such turn")
+
+// ErrGameOver represents a move made after a player has gone out
+var ErrGameOver = fmt.Errorf("game is over, no more moves can be made")
+
 // Errors related to database interactions
 var (
 	ErrCouldNotUpdatePlayerState = fmt.Errorf("player state update called, could not update")
@@ -51,3 +111,168 @@ type ErrInvalidWords struct {
 func (e ErrInvalidWords) Error() string {
 	return fmt.Sprintf("Invalid words: %v", e.failedWords)
 }
+
+// ErrVerificationFailed represents a replayed game that does not match the recorded game
+type ErrVerificationFailed struct {
+	Turn   int
+	Reason string
+}
+
+func (e ErrVerificationFailed) Error() string {
+	return fmt.Sprintf("Verification failed at turn %v: %s", e.Turn, e.Reason)
+}
+
+// ErrLeaveFormat represents a line of a leave table that could not be parsed
+type ErrLeaveFormat struct {
+	Line int
+}
+
+func (e ErrLeaveFormat) Error() string {
+	return fmt.Sprintf("Could not parse leave table: line %v must be `LEAVE VALUE`", e.Line)
+}
+
+// ErrSelfPlayFailed represents a self play game where a computer player made an illegal move
+type ErrSelfPlayFailed struct {
+	Game  int
+	Input string
+	Err   error
+}
+
+func (e ErrSelfPlayFailed) Error() string {
+	return fmt.Sprintf("Self play game %v failed on %q: %v", e.Game, e.Input, e.Err)
+}
+
+// ErrNotation represents a play in notation that could not be parsed
+// @Position index of the offending character within the input
+type ErrNotation struct {
+	Input    string
+	Position int
+	Reason   string
+}
+
+func (e ErrNotation) Error() string {
+	return fmt.Sprintf("Could not parse move at character %v: %s\n%s\n%s^", e.Position+1, e.Reason, e.Input, strings.Repeat(" ", e.Position))
+}
+
+// ErrPositionSetup represents a position set up by hand that cannot be played from
+type ErrPositionSetup struct {
+	Reason string
+}
+
+func (e ErrPositionSetup) Error() string {
+	return fmt.Sprintf("Could not set up position: %s", e.Reason)
+}
+
+// ErrCGP represents a position in one line notation that could not be read
+type ErrCGP struct {
+	Reason string
+}
+
+func (e ErrCGP) Error() string {
+	return fmt.Sprintf("Could not read position: %s", e.Reason)
+}
+
+// ErrScenarioFormat represents a line of a scenario file that could not be parsed
+type ErrScenarioFormat struct {
+	Line   int
+	Reason string
+}
+
+func (e ErrScenarioFormat) Error() string {
+	return fmt.Sprintf("Could not parse scenario: line %v: %s", e.Line, e.Reason)
+}
+
+// ErrLayoutFormat represents a line of a layout file that could not be parsed
+type ErrLayoutFormat struct {
+	Line   int
+	Reason string
+}
+
+func (e ErrLayoutFormat) Error() string {
+	return fmt.Sprintf("Could not parse layout: line %v: %s", e.Line, e.Reason)
+}
+
+// ErrUnknownTileSet represents a tile set name that is not built in
+type ErrUnknownTileSet struct {
+	Name string
+}
+
+func (e ErrUnknownTileSet) Error() string {
+	return fmt.Sprintf("Unknown tile set %q, expected one of %v", e.Name, TileSetNames())
+}
+
+// ErrUnknownRuleSet represents a rule set name that is not a preset
+type ErrUnknownRuleSet struct {
+	Name string
+}
+
+func (e ErrUnknownRuleSet) Error() string {
+	return fmt.Sprintf("Unknown rules %q, expected one of %v", e.Name, RuleSetNames())
+}
+
+// ErrUnknownPlayer represents a player name that is not part of the game
+type ErrUnknownPlayer struct {
+	Name string
+}
+
+func (e ErrUnknownPlayer) Error() string {
+	return fmt.Sprintf("No player named %q in the game", e.Name)
+}
+
+// ErrRoundIncomplete represents a duplicate round ended before every player submitted a play
+type ErrRoundIncomplete struct {
+	Waiting []string
+}
+
+func (e ErrRoundIncomplete) Error() string {
+	return fmt.Sprintf("Round is not over, waiting for %s", strings.Join(e.Waiting, ", "))
+}
+
+// ErrScenarioMove represents a move of a scenario that could not be applied
+type ErrScenarioMove struct {
+	Line  int
+	Turn  int
+	Input string
+	Err   error
+}
+
+func (e ErrScenarioMove) Error() string {
+	return fmt.Sprintf("Move %q on line %v (turn %v) failed: %v", e.Input, e.Line, e.Turn, e.Err)
+}
+
+// ErrorCode returns a stable name for an error, for programs that react to specific failures
+func ErrorCode(err error) string {
+	switch err {
+	case nil:
+		return ""
+	case ErrNoValidWordsFound:
+		return "no_words"
+	case ErrTileNotInHand:
+		return "tile_not_in_hand"
+	case ErrNotEnoughTilesForSwap:
+		return "not_enough_tiles"
+	case ErrWordDisconnected:
+		return "disconnected"
+	case ErrInvalidPlacement:
+		return "invalid_placement"
+	case ErrInvalidSpace:
+		return "invalid_space"
+	case ErrInvalidStart:
+		return "invalid_start"
+	case ErrInvalidAction:
+		return "invalid_action"
+	case ErrGameOver:
+		return "game_over"
+	case ErrTileFormat, ErrInvalidIndex:
+		return "tile_format"
+	}
+	switch err.(type) {
+	case ErrSpaceOccupied:
+		return "space_occupied"
+	case ErrInvalidWords:
+		return "invalid_words"
+	case ErrNotation:
+		return "notation"
+	}
+	return "error"
+}
@@ -0,0 +1,136 @@
+package scrabble
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// commit generates a secret salt and records the hash of the bag seed
+// the hash is shown to players at the start, the seed and salt are revealed at the end
+func (game *Game) commit() {
+	salt := make([]byte, 16)
+	_, err := rand.Read(salt)
+	if err != nil {
+		panic(err)
+	}
+	game.salt = hex.EncodeToString(salt)
+	game.commitment = commitmentFor(game.seed(), game.salt)
+}
+
+// commitmentFor hashes the seed and salt into the published commitment
+func commitmentFor(seed int64, salt string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s", seed, salt)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Commitment returns the hash of the bag seed that players can check after the game
+func (game Game) Commitment() string {
+	return game.commitment
+}
+
+// Reveal returns the seed and salt behind the commitment once the game is finished
+func (game Game) Reveal() (int64, string, error) {
+	if !game.finished {
+		return 0, "", ErrGameInProgress
+	}
+	return game.seed(), game.salt, nil
+}
+
+// VerifyGame confirms a finished game was played from the committed bag
+// checks the revealed seed against the commitment, then replays every turn
+// from a fresh bag and compares the scores, racks and remaining tiles
+func VerifyGame(game *Game) error {
+	seed, salt, err := game.Reveal()
+	if err != nil {
+		return err
+	}
+	if game.commitment == "" {
+		return ErrNoCommitment
+	}
+	if commitmentFor(seed, salt) != game.commitment {
+		return ErrCommitmentMismatch
+	}
+
+	replay, err := newReplay(game)
+	if err != nil {
+		return err
+	}
+
+	for _, turn := range game.Turns {
+		result, err := replay.replayTurn(turn.input)
+		if err != nil {
+			return ErrVerificationFailed{
+				Turn:   turn.number,
+				Reason: fmt.Sprintf("could not replay %q: %v", turn.input, err),
+			}
+		}
+		if result.Score != turn.score {
+			return ErrVerificationFailed{
+				Turn:   turn.number,
+				Reason: fmt.Sprintf("recorded score %v, replayed score %v", turn.score, result.Score),
+			}
+		}
+	}
+
+	for i, p := range game.players {
+		if !sameTiles(p.tiles, replay.players[i].tiles) {
+			return ErrVerificationFailed{
+				Turn:   len(game.Turns),
+				Reason: fmt.Sprintf("%s holds %v, bag dealt %v", p.Name, p.tiles, replay.players[i].tiles),
+			}
+		}
+	}
+	if !sameTiles(game.Tiles.Remaining, replay.Tiles.Remaining) {
+		return ErrVerificationFailed{
+			Turn:   len(game.Turns),
+			Reason: "remaining tiles in the bag do not match the committed bag",
+		}
+	}
+	return nil
+}
+
+// newReplay sets up a fresh copy of a seeded game as it was before the first turn
+// players draw from a new bag in turn order, exactly as they did when the game was created
+func newReplay(game *Game) (*Game, error) {
+	if game.Tiles.Ordered {
+		return nil, ErrOrderedBag
+	}
+	if len(game.players) == 0 {
+		return nil, ErrVerificationFailed{Reason: "game has no players"}
+	}
+
+	replay := Game{
+		board:      game.board.Layout().NewBoard(),
+		Tiles:      InitializeTileSet(game.TileSet(), game.seed()),
+		Dictionary: game.Dictionary,
+		rules:      game.rules,
+	}
+	for _, p := range game.players {
+		replay.players = append(replay.players, Player{
+			id:     p.id,
+			nextID: p.nextID,
+			Name:   p.Name,
+			Team:   p.Team,
+			tiles:  replay.dealRack(p.Team),
+		})
+	}
+	replay.Turn = Turn{
+		number: 1,
+		player: replay.players[0],
+	}
+	return &replay, nil
+}
+
+func sameTiles(a, b []Tile) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
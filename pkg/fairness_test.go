@@ -0,0 +1,169 @@
+package scrabble
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+// playToEnd plays the best move of each player for a few turns, then passes until the game is over
+func playToEnd(t *testing.T, game *Game, plays int, gameDB *GameDB) {
+	t.Helper()
+	for turn := 0; !game.IsOver(); turn++ {
+		input := "swap"
+		if turn < plays {
+			if moves := game.Moves(DefaultLeaves); len(moves) > 0 {
+				input = moves[0].Input()
+			}
+		}
+		if _, err := game.ApplyTurn(input, gameDB); err != nil {
+			t.Fatalf("turn %v: %s: %v", turn, input, err)
+		}
+	}
+	game.End()
+}
+
+// newFairGame starts a two player game from a seeded bag using the full dictionary
+// under the classic rules, so passing until the scoreless turn limit ends the game
+func newFairGame(t *testing.T, seed int64, gameDB *GameDB) *Game {
+	t.Helper()
+	dict, err := LoadDictionary(filepath.Join("..", dictPath))
+	if err != nil {
+		t.Skipf("dictionary not available: %v", err)
+	}
+	return NewGameWithOptions([]PlayerRequest{{Name: "alice"}, {Name: "bob"}}, GameOptions{Seed: seed, Dictionary: &dict, Rules: &ClassicRules}, gameDB)
+}
+
+func TestVerifyGame(t *testing.T) {
+	game := newFairGame(t, 42, nil)
+	commitment := game.Commitment()
+	if commitment == "" {
+		t.Fatal("a new game should publish a commitment")
+	}
+	if _, _, err := game.Reveal(); err != ErrGameInProgress {
+		t.Errorf("reveal before the end: got %v", err)
+	}
+	if err := VerifyGame(game); err != ErrGameInProgress {
+		t.Errorf("verify before the end: got %v", err)
+	}
+
+	playToEnd(t, game, 6, nil)
+	if game.Commitment() != commitment {
+		t.Error("commitment changed during the game")
+	}
+	seed, salt, err := game.Reveal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seed != 42 || commitmentFor(seed, salt) != commitment {
+		t.Errorf("revealed seed %v and salt %v do not match the commitment", seed, salt)
+	}
+	if err := VerifyGame(game); err != nil {
+		t.Fatalf("finished game: %v", err)
+	}
+	if len(game.Tiles.Remaining) < 2 {
+		t.Fatal("the bag should still hold tiles to reorder")
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(g *Game)
+		want   error
+	}{
+		{"seed", func(g *Game) { g.Tiles.seed++ }, ErrCommitmentMismatch},
+		{"salt", func(g *Game) { g.salt += "0" }, ErrCommitmentMismatch},
+		{"no commitment", func(g *Game) { g.commitment = "" }, ErrNoCommitment},
+		{"rack", func(g *Game) {
+			rack := append([]Tile(nil), g.players[0].tiles...)
+			rack[0] = EnglishTiles.Tile("Z")
+			if g.players[0].tiles[0].Letter == "Z" {
+				rack[0] = EnglishTiles.Tile("Q")
+			}
+			g.players[0].tiles = rack
+		}, nil},
+		{"bag order", func(g *Game) {
+			bag := append([]Tile(nil), g.Tiles.Remaining...)
+			for i := 1; i < len(bag); i++ {
+				if bag[i] != bag[0] {
+					bag[0], bag[i] = bag[i], bag[0]
+					break
+				}
+			}
+			g.Tiles.Remaining = bag
+		}, nil},
+		{"score", func(g *Game) {
+			turns := append([]Turn(nil), g.Turns...)
+			turns[0].score++
+			g.Turns = turns
+		}, nil},
+	}
+	for _, test := range tests {
+		tampered := *game
+		tampered.players = append([]Player(nil), game.players...)
+		test.tamper(&tampered)
+		err := VerifyGame(&tampered)
+		if test.want != nil {
+			if err != test.want {
+				t.Errorf("%s: got %v, want %v", test.name, err, test.want)
+			}
+		} else if _, ok := err.(ErrVerificationFailed); !ok {
+			t.Errorf("%s: got %v, want a failed verification", test.name, err)
+		}
+	}
+	if err := VerifyGame(game); err != nil {
+		t.Errorf("tampering with copies changed the game: %v", err)
+	}
+}
+
+func TestVerifyOrderedBag(t *testing.T) {
+	game := newTestGame(t, "CATSDOGEEIRNTS", "CATS")
+	// casual games never end on passes alone
+	game.rules = ClassicRules
+	playToEnd(t, game, 0, nil)
+	if err := VerifyGame(game); err != ErrOrderedBag {
+		t.Errorf("got %v, want %v", err, ErrOrderedBag)
+	}
+}
+
+func TestCommitmentSavedAndLoaded(t *testing.T) {
+	gameDB := newTestDB(t)
+	game := newFairGame(t, 7, gameDB)
+	loaded, err := gameDB.GetGameByID(int(game.GetID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Commitment() != game.Commitment() || loaded.salt != game.salt || loaded.seed() != 7 {
+		t.Errorf("new game: loaded commitment %v, saved %v", loaded.Commitment(), game.Commitment())
+	}
+
+	// the seed is saved in its own column, the bag is stored without it
+	data, err := json.Marshal(game.Tiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bag map[string]interface{}
+	if err := json.Unmarshal(data, &bag); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := bag["Seed"]; ok || len(bag["Remaining"].([]interface{})) != len(game.Tiles.Remaining) {
+		t.Errorf("bag saved as %s", data)
+	}
+
+	playToEnd(t, loaded, 6, gameDB)
+	if err := gameDB.UpsertGame(loaded); err != nil {
+		t.Fatal(err)
+	}
+	finished, err := gameDB.GetGameByID(int(game.GetID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if finished.Commitment() != game.Commitment() {
+		t.Errorf("finished game: loaded commitment %v, saved %v", finished.Commitment(), game.Commitment())
+	}
+	if !finished.IsFinished() {
+		t.Fatal("the game should be saved as finished")
+	}
+	if err := VerifyGame(finished); err != nil {
+		t.Errorf("loaded game: %v", err)
+	}
+}
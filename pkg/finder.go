@@ -0,0 +1,220 @@
+package scrabble
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sort orders supported by word searches
+const (
+	SortAlphabetical = "alpha"
+	SortLength       = "length"
+	SortScore        = "score"
+)
+
+// WordQuery represents a search over the dictionary
+// @Anagram letters that must all be used, `?` or `_` for blanks
+// @Build letters that may be used, any word formed from some of them matches
+// @Pattern word shape where `?` matches any single letter (ex: ?AZ?)
+// @Contains, @StartsWith letters the word must contain or begin with
+// @MinLength, @MaxLength bounds on the word length, 0 for no bound
+// @SortBy one of SortAlphabetical, SortLength or SortScore
+type WordQuery struct {
+	Anagram    string
+	Build      string
+	Pattern    string
+	Contains   string
+	StartsWith string
+	MinLength  int
+	MaxLength  int
+	SortBy     string
+}
+
+// FoundWord represents a word matching a query
+// @Score value of the word's tiles, letters covered by blanks are worth nothing
+type FoundWord struct {
+	Word  string
+	Score int
+}
+
+func (f FoundWord) String() string {
+	return fmt.Sprintf("%s (%v)", f.Word, f.Score)
+}
+
+// Find searches the dictionary for every word matching the query
+func (d Dictionary) Find(q WordQuery) []FoundWord {
+	q.Anagram = normalizeQuery(q.Anagram)
+	q.Build = normalizeQuery(q.Build)
+	q.Pattern = normalizeQuery(q.Pattern)
+	q.Contains = strings.ToUpper(q.Contains)
+	q.StartsWith = strings.ToUpper(q.StartsWith)
+
+	lex := d.index()
+	var found []FoundWord
+	switch {
+	case q.Anagram != "":
+		found = lex.findAnagrams(q.Anagram, true)
+	case q.Build != "":
+		found = lex.findAnagrams(q.Build, false)
+	case q.Pattern != "":
+		found = lex.findPattern(q.Pattern)
+	default:
+		for w := range d.Words {
+			found = append(found, FoundWord{Word: w, Score: lex.wordScore(w)})
+		}
+	}
+
+	var matches []FoundWord
+	for _, f := range found {
+		if q.Pattern != "" && !lex.matchesPattern(f.Word, q.Pattern) {
+			continue
+		}
+		if !strings.Contains(f.Word, q.Contains) || !strings.HasPrefix(f.Word, q.StartsWith) {
+			continue
+		}
+		length := len(lex.split(f.Word))
+		if (q.MinLength > 0 && length < q.MinLength) || (q.MaxLength > 0 && length > q.MaxLength) {
+			continue
+		}
+		matches = append(matches, f)
+	}
+
+	lex.sortFound(matches, q.SortBy)
+	return matches
+}
+
+// findAnagrams walks the trie using the letters of the rack
+// exact requires every letter to be used, otherwise any subset of at least two letters
+func (lex *lexicon) findAnagrams(letters string, exact bool) []FoundWord {
+	rack := make([]int, len(lex.alphabet)+1)
+	blank := len(lex.alphabet)
+	var total int
+	for _, l := range lex.split(letters) {
+		if l == "_" {
+			rack[blank]++
+		} else if i, ok := lex.letters[l]; ok {
+			rack[i]++
+		} else if exact {
+			// a letter found in no word means no anagram exists
+			return nil
+		}
+		total++
+	}
+
+	seen := make(map[string]bool)
+	var found []FoundWord
+	var word []string
+	var walk func(node *trieNode, used, score int)
+	walk = func(node *trieNode, used, score int) {
+		if node.terminal && ((exact && used == total) || (!exact && used > 1)) {
+			w := strings.Join(word, "")
+			if !seen[w] {
+				seen[w] = true
+				found = append(found, FoundWord{Word: w, Score: score})
+			}
+		}
+		for _, e := range node.edges {
+			letter := lex.alphabet[e.letter]
+			// prefer real tiles over blanks so scores are as high as possible
+			for _, held := range []int{e.letter, blank} {
+				if rack[held] == 0 {
+					continue
+				}
+				value := 0
+				if held != blank {
+					value = lex.tiles.Values[letter]
+				}
+				rack[held]--
+				word = append(word, letter)
+				walk(e.node, used+1, score+value)
+				word = word[:len(word)-1]
+				rack[held]++
+				break
+			}
+		}
+	}
+	walk(lex.root, 0, 0)
+	return found
+}
+
+// findPattern walks the trie following the fixed letters of the pattern
+func (lex *lexicon) findPattern(pattern string) []FoundWord {
+	shape := lex.split(pattern)
+	var found []FoundWord
+	var word []string
+	var walk func(node *trieNode, pos int)
+	walk = func(node *trieNode, pos int) {
+		if pos == len(shape) {
+			if node.terminal {
+				w := strings.Join(word, "")
+				found = append(found, FoundWord{Word: w, Score: lex.wordScore(w)})
+			}
+			return
+		}
+		for _, e := range node.edges {
+			letter := lex.alphabet[e.letter]
+			if shape[pos] != "_" && shape[pos] != letter {
+				continue
+			}
+			word = append(word, letter)
+			walk(e.node, pos+1)
+			word = word[:len(word)-1]
+		}
+	}
+	walk(lex.root, 0)
+	return found
+}
+
+// matchesPattern checks a word against a pattern of letters and blanks
+func (lex *lexicon) matchesPattern(word, pattern string) bool {
+	letters, shape := lex.split(word), lex.split(pattern)
+	if len(letters) != len(shape) {
+		return false
+	}
+	for i := range shape {
+		if shape[i] != "_" && shape[i] != letters[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeQuery upper cases a query and converts `?` blanks to `_`
+func normalizeQuery(query string) string {
+	return strings.ReplaceAll(strings.ToUpper(query), "?", "_")
+}
+
+// wordScore adds up the face value of every letter of a word
+func (lex *lexicon) wordScore(word string) int {
+	var score int
+	for _, l := range lex.split(word) {
+		score += lex.tiles.Values[l]
+	}
+	return score
+}
+
+// sortFound orders the results, ties are always broken alphabetically
+// lengths are counted in tiles so a word with CH or LL is not longer than its tiles
+func (lex *lexicon) sortFound(found []FoundWord, by string) {
+	lengths := make(map[string]int, len(found))
+	if by == SortLength {
+		for _, f := range found {
+			lengths[f.Word] = len(lex.split(f.Word))
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		switch by {
+		case SortLength:
+			if lengths[a.Word] != lengths[b.Word] {
+				return lengths[a.Word] > lengths[b.Word]
+			}
+		case SortScore:
+			if a.Score != b.Score {
+				return a.Score > b.Score
+			}
+		}
+		return a.Word < b.Word
+	})
+}
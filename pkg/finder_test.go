@@ -0,0 +1,38 @@
+package scrabble
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	dict := NewDictionary([]string{"SATINE", "TINEAS", "ENTASIA", "HAZE", "MAZE", "ADZE", "ADZ", "QUIZ", "CAT", "CATS", "AT"})
+	tests := []struct {
+		name  string
+		query WordQuery
+		want  []FoundWord
+	}{
+		{"anagram", WordQuery{Anagram: "aeinst"}, []FoundWord{{"SATINE", 6}, {"TINEAS", 6}}},
+		{"anagram with a blank", WordQuery{Anagram: "AEINST?"}, []FoundWord{{"ENTASIA", 6}}},
+		{"anagram without a match", WordQuery{Anagram: "XYZ"}, nil},
+		{"build", WordQuery{Build: "CATS"}, []FoundWord{{"AT", 2}, {"CAT", 5}, {"CATS", 6}}},
+		{"pattern", WordQuery{Pattern: "?AZ?"}, []FoundWord{{"HAZE", 16}, {"MAZE", 15}}},
+		{"contains by score", WordQuery{Contains: "z", SortBy: SortScore}, []FoundWord{{"QUIZ", 22}, {"HAZE", 16}, {"MAZE", 15}, {"ADZE", 14}, {"ADZ", 13}}},
+		{"starts with by length", WordQuery{StartsWith: "ca", SortBy: SortLength}, []FoundWord{{"CATS", 6}, {"CAT", 5}}},
+		{"length bounds", WordQuery{Contains: "A", MinLength: 3, MaxLength: 3}, []FoundWord{{"ADZ", 13}, {"CAT", 5}}},
+		{"pattern and contains", WordQuery{Pattern: "??ZE", Contains: "M"}, []FoundWord{{"MAZE", 15}}},
+	}
+	for _, tt := range tests {
+		if got := dict.Find(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// CH and LL are single tiles, CHICO and LLAMA are as long as CASA
+	spanish := NewDictionary([]string{"CHICO", "LLAMA", "CASA", "COSAS"})
+	spanish.tiles = &SpanishTiles
+	want := []FoundWord{{"COSAS", 7}, {"CASA", 6}, {"CHICO", 10}, {"LLAMA", 13}}
+	if got := spanish.Find(WordQuery{SortBy: SortLength}); !reflect.DeepEqual(got, want) {
+		t.Errorf("spanish by length: got %v, want %v", got, want)
+	}
+}
@@ -10,16 +10,10 @@ import (
 // Constants of the game
 const (
 	HandSize = 7
-	Size     = 15
 	dictPath = "data/dictionary.txt"
 	BINGO    = 50
 )
 
-var (
-	// CENTER represents middle of board
-	CENTER = Coordinate{7, 7}
-)
-
 // Game represents an active state of a game of scrabble
 type Game struct {
 	id         int64
@@ -29,6 +23,10 @@ type Game struct {
 	Dictionary Dictionary
 	Turn       Turn
 	Turns      []Turn
+	commitment string
+	salt       string
+	finished   bool
+	rules      RuleSet
 }
 
 // Turn represents a unit of action driving the game
@@ -40,11 +38,15 @@ type Turn struct {
 }
 
 // Result represents a struct response for a requested turn
+// @Placed tiles laid on the board by a play, a bingo when the whole rack
+// @Invalid words of a play withdrawn under the withdraw rule
 type Result struct {
 	Words   []Word
 	Score   int
 	Swapped int
+	Placed  int
 	Action  string
+	Invalid []string
 }
 
 func (r Result) String() string {
@@ -53,6 +55,8 @@ func (r Result) String() string {
 		return fmt.Sprintf("successfully swapped %v tiles", r.Swapped)
 	case "place":
 		return fmt.Sprintf("successfully placed %v for %v points", r.Words, r.Score)
+	case "challenged":
+		return fmt.Sprintf("play withdrawn, invalid words %v, the turn is lost", r.Invalid)
 	}
 	return "no action implemented"
 }
@@ -67,11 +71,31 @@ func (game Game) GetPlayers() []Player {
 	return game.players
 }
 
+// IsFinished indicates the game has ended and been scored
+func (game Game) IsFinished() bool {
+	return game.finished
+}
+
 // GetID returns the id of the game
 func (game Game) GetID() int64 {
 	return game.id
 }
 
+// seed returns the seed used for the tile bag and player ordering, see Reveal for players
+func (game Game) seed() int64 {
+	return game.Tiles.seed
+}
+
+// TileSet returns the tile distribution the game is played with
+func (game Game) TileSet() TileSet {
+	return game.Tiles.TileSet()
+}
+
+// Rules returns the rules the game is played with
+func (game Game) Rules() RuleSet {
+	return game.rules.orDefault()
+}
+
 // SetPlayerState takes a changed player condition and updates
 // Search using name of player
 // TODO consider either mapping names --> players
@@ -96,26 +120,104 @@ func (game *Game) Draw(num int) []Tile {
 	return game.Tiles.Draw(num)
 }
 
+// GameOptions represents optional configuration for creating a game
+// @Seed seeds the tile bag and player ordering, 0 picks a random seed
+// @Bag pre-orders the tile bag, tiles are drawn in the given order without shuffling
+// @Dictionary shares an already loaded dictionary, the default dictionary is loaded when nil
+// @FixedOrder players take turns in the order requested instead of a seeded shuffle
+// @Layout geometry and premium squares of the board, the standard board when nil
+// @TileSet distribution and values of the tiles, english when nil, its dictionary is loaded unless one is shared
+// @Rules rules of the game, the casual rules when nil, its layout and tile set are used unless others are given
+// @SharedRack partners of a team play from one rack, whatever the rules say
+type GameOptions struct {
+	Seed       int64
+	Bag        []Tile
+	Dictionary *Dictionary
+	FixedOrder bool
+	Layout     *Layout
+	TileSet    *TileSet
+	Rules      *RuleSet
+	SharedRack bool
+}
+
 // NewGame begins a new game of scrabble
 // Instantiates the tiles
 func NewGame(playerReq []PlayerRequest, gameDB *GameDB) *Game {
-	tiles := InitializeTiles()
-	board := NewBoard()
+	return NewGameWithOptions(playerReq, GameOptions{}, gameDB)
+}
+
+// NewGameWithOptions begins a new game of scrabble using the provided options
+// Instantiates the tiles from the requested seed or bag order
+func NewGameWithOptions(playerReq []PlayerRequest, opts GameOptions, gameDB *GameDB) *Game {
+	seed := opts.Seed
+	for seed == 0 {
+		seed = rand.Int63()
+	}
+
+	rules := CasualRules
+	if opts.Rules != nil {
+		rules = *opts.Rules
+	}
+	ts, layout, err := rules.TileSetLayout()
+	if err != nil {
+		panic(err)
+	}
+	if opts.TileSet != nil {
+		ts = *opts.TileSet
+	}
+	if opts.Layout != nil {
+		layout = *opts.Layout
+	}
+	// the stored rules name the board and tiles actually played with
+	rules.Layout, rules.TileSet = layout.Name, ts.Name
+	if opts.SharedRack {
+		rules.SharedRack = true
+	}
+
+	var tiles Tiles
+	if opts.Bag != nil {
+		tiles = NewOrderedTiles(opts.Bag)
+		tiles.seed = seed
+		if ts.Name != EnglishTiles.Name {
+			tiles.Set = ts.Name
+		}
+	} else {
+		tiles = InitializeTileSet(ts, seed)
+	}
 	game := Game{
-		board:   board,
+		board:   layout.NewBoard(),
 		players: []Player{},
 		Tiles:   tiles,
+		rules:   rules,
 	}
+	game.commit()
 
-	err := game.AddPlayers(playerReq, gameDB)
-	if err != nil {
-		panic(err)
+	if opts.FixedOrder {
+		err = game.addPlayersInOrder(playerReq, gameDB)
+	} else {
+		err = game.AddPlayers(playerReq, gameDB)
 	}
-	dict, err := LoadDictionary(dictPath)
 	if err != nil {
 		panic(err)
 	}
-	game.Dictionary = dict
+	if opts.Dictionary != nil {
+		game.Dictionary = *opts.Dictionary
+	} else {
+		dict, err := LoadTileSetDictionary(ts.Dictionary, ts)
+		if err != nil {
+			panic(err)
+		}
+		game.Dictionary = dict
+	}
+
+	// games without a db are kept purely in memory (replays, simulations)
+	if gameDB == nil {
+		game.Turn = Turn{
+			player: game.players[0],
+			number: 1,
+		}
+		return &game
+	}
 
 	err = gameDB.UpsertGame(&game)
 	if err != nil {
@@ -127,7 +229,8 @@ func NewGame(playerReq []PlayerRequest, gameDB *GameDB) *Game {
 // LoadFromState loads a pre-existing game
 func LoadFromState(board Board, tiles Tiles, players []Player, turn Turn) Game {
 
-	dict, err := LoadDictionary(dictPath)
+	ts := tiles.TileSet()
+	dict, err := LoadTileSetDictionary(ts.Dictionary, ts)
 	if err != nil {
 		panic(err)
 	}
@@ -142,27 +245,49 @@ func LoadFromState(board Board, tiles Tiles, players []Player, turn Turn) Game {
 }
 
 // AddPlayers instantiates players into the game
+// players are ordered using the game seed, then draw their hands in turn order
 func (game *Game) AddPlayers(playerRequests []PlayerRequest, gameDB *GameDB) error {
-	for _, p := range playerRequests {
+	requests := make([]PlayerRequest, len(playerRequests))
+	copy(requests, playerRequests)
+
+	// shuffle players for who goes first (and ordering)
+	rng := rand.New(rand.NewSource(game.seed()))
+	for i := 0; i < shuffleLoop; i++ {
+		rng.Shuffle(len(requests), func(i, j int) {
+			requests[i], requests[j] = requests[j], requests[i]
+		})
+	}
+	return game.addPlayersInOrder(requests, gameDB)
+}
+
+// addPlayersInOrder instantiates players who take turns in the order of the requests
+// teams are seated so they alternate, partners sharing a rack are dealt a single rack
+func (game *Game) addPlayersInOrder(requests []PlayerRequest, gameDB *GameDB) error {
+	requests, err := seatTeams(requests)
+	if err != nil {
+		return err
+	}
+	for _, p := range requests {
 		player := Player{
 			Name:         p.Name,
 			UsePlainText: p.UsePlainText,
-			tiles:        game.Draw(HandSize),
+			Bot:          p.Bot,
+			Engine:       p.Engine,
+			Team:         p.Team,
+			tiles:        game.dealRack(p.Team),
 		}
-		err := gameDB.InsertPlayer(&player)
-		if err != nil {
-			return err
+		if gameDB != nil {
+			err := gameDB.InsertPlayer(&player)
+			if err != nil {
+				return err
+			}
+		} else {
+			// in memory players only need to be unique within the game
+			player.id = int64(len(game.players) + 1)
 		}
 		game.players = append(game.players, player)
 	}
 
-	// shuffle players for who goes first (and ordering)
-	for i := 0; i < shuffleLoop; i++ {
-		rand.Shuffle(len(game.players), func(i, j int) {
-			game.players[i], game.players[j] = game.players[j], game.players[i]
-		})
-	}
-
 	// loops through players and links them to who is next
 	for i := 0; i < len(game.players); i++ {
 		game.players[i].nextID = game.players[(i+1)%len(game.players)].id
@@ -193,15 +318,126 @@ func (game *Game) SetNextTurn() {
 	}
 }
 
+// IsOver checks if a player has gone out, using every tile in the bag and on their rack
+// or the rules limit on scoreless turns in a row has been reached
+func (game *Game) IsOver() bool {
+	for _, p := range game.players {
+		if len(p.tiles) == 0 {
+			return true
+		}
+	}
+	limit := game.Rules().ScorelessTurns
+	return limit > 0 && game.ScorelessTurns() >= limit
+}
+
+// ScorelessTurns counts the turns in a row, up to the latest, that scored no points
+func (game *Game) ScorelessTurns() int {
+	var count int
+	for i := len(game.Turns) - 1; i >= 0 && game.Turns[i].score == 0; i-- {
+		count++
+	}
+	return count
+}
+
 // End enters the final scoring of the game
+// marks the game as finished which allows the bag commitment to be revealed
+// a game ended by scoreless turns has no player going out, everyone loses their rack
+// in a team game a player going out gains the racks of the other teams, the winner is a team (see WinningTeam)
+// and no single player is returned, nor is anybody on a tie (see Winner)
 func (game *Game) End() Player {
+	if game.finished {
+		return game.Winner()
+	}
+	game.finished = true
+
+	// every player loses the value of their rack, a player who went out gains the total
+	out := -1
+	scores := make([]int, len(game.players))
+	racks := make([][]Tile, len(game.players))
+	for i, p := range game.players {
+		scores[i] = p.score
+		racks[i] = p.tiles
+		if len(p.tiles) == 0 {
+			out = i
+		}
+	}
+	if game.IsTeamGame() {
+		endTeamAdjustment(scores, racks, game.playerTeams(), game.Rules().SharedRack, out)
+	} else {
+		endRackAdjustment(scores, racks, out)
+	}
+	for i := range game.players {
+		game.players[i].score = scores[i]
+	}
+
+	return game.Winner()
+}
+
+// Winner is the player with the best score, nobody in a team game (see WinningTeam),
+// when the best score is shared or when nobody scored above zero
+func (game *Game) Winner() Player {
+	top, leaders := game.leaders()
+	if game.IsTeamGame() || leaders != 1 || top <= 0 {
+		return Player{}
+	}
 	for _, p := range game.players {
-		for _, t := range p.Tiles() {
-			p.score = p.score - t.Value
+		if p.score == top {
+			return p
+		}
+	}
+	return Player{}
+}
+
+// Won checks whether the player won the game alone, in a team game whether their team won
+func (game *Game) Won(player Player) bool {
+	top, leaders := game.leaders()
+	return leaders == 1 && top > 0 && game.sideScore(player) == top
+}
+
+// IsTie checks whether the best score is shared by several players, or teams in a team game
+func (game *Game) IsTie() bool {
+	_, leaders := game.leaders()
+	return leaders > 1
+}
+
+// Tied checks whether the player shares the best score, in a team game whether their team does
+func (game *Game) Tied(player Player) bool {
+	top, leaders := game.leaders()
+	return leaders > 1 && game.sideScore(player) == top
+}
+
+// leaders returns the best score and how many players reached it, how many teams in a team game
+func (game *Game) leaders() (int, int) {
+	var scores []int
+	if game.IsTeamGame() {
+		for _, t := range game.Teams() {
+			scores = append(scores, t.Score)
+		}
+	} else {
+		for _, p := range game.players {
+			scores = append(scores, p.score)
 		}
 	}
+	var top, count int
+	for i, score := range scores {
+		switch {
+		case i == 0 || score > top:
+			top, count = score, 1
+		case score == top:
+			count++
+		}
+	}
+	return top, count
+}
 
-	return game.HighestScore()
+// sideScore is the score of the player, the shared score of their team in a team game
+func (game *Game) sideScore(player Player) int {
+	for _, t := range game.Teams() {
+		if t.Name == player.Team {
+			return t.Score
+		}
+	}
+	return player.score
 }
 
 // HighestScore finds the player who has the highest current score
@@ -216,18 +452,41 @@ func (game *Game) HighestScore() Player {
 }
 
 // CheckWord validates the input string
+// under the clabbers rule any anagram of a word is valid
 func (game *Game) CheckWord(word string) bool {
+	if game.Rules().Clabbers {
+		return game.Dictionary.HasAnagram(game.TileSet().Split(word))
+	}
 	return game.Dictionary.Words[word]
 }
 
 // ApplyTurn parses user input and
 func (game *Game) ApplyTurn(input string, gameDB *GameDB) (Result, error) {
+	return game.applyTurn(input, gameDB, false)
+}
+
+// replayTurn applies a turn read back from a saved game, which may be a withdrawn play
+func (game *Game) replayTurn(input string) (Result, error) {
+	return game.applyTurn(input, nil, true)
+}
+
+// applyTurn plays the input for the current player
+// `challenged` marks a play withdrawn under the withdraw rule, it is only accepted when replaying a saved game
+func (game *Game) applyTurn(input string, gameDB *GameDB, replaying bool) (Result, error) {
 	var err error
 	var placements []TilePlacement
 	var score int
 	var words []Word
 	var result Result
 
+	// plays in notation are stored in the same form as placed tiles
+	if isNotation(input) {
+		input, err = game.expandNotation(input)
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
 	tokens := strings.Split(input, " ")
 	if len(tokens) == 0 {
 		return Result{}, ErrInvalidAction
@@ -238,32 +497,50 @@ func (game *Game) ApplyTurn(input string, gameDB *GameDB) (Result, error) {
 	switch result.Action {
 	case "swap":
 		// Format of `swap a b c d`
-		tiles := parseTiles(tokens)
+		tiles := game.TileSet().parseTiles(tokens)
 		err = game.SwapTiles(tiles)
 		result.Swapped = len(tiles)
 
 	case "place":
 		// Format of `place a(1,a) b(2,a)`
-		placements, err = parseTilePlacements(tokens)
+		placements, err = game.TileSet().parseTilePlacements(tokens)
 		if err != nil {
 			return Result{}, err
 		}
 		words, score, err = game.PlaceTiles(placements)
+		if invalid, ok := err.(ErrInvalidWords); ok && game.Rules().Challenge == ChallengeWithdraw {
+			// the phony is withdrawn and recorded, replays lose the same turn
+			input = "challenged " + input
+			result.Action, result.Invalid = "challenged", invalid.failedWords
+			err = nil
+		}
 		result.Words = words
 		result.Score = score
+		if result.Action == "place" {
+			result.Placed = len(placements)
+		}
+	case "challenged":
+		// a withdrawn play read back from a saved game, the tiles stay on the rack
+		if !replaying || game.Rules().Challenge != ChallengeWithdraw {
+			return Result{}, ErrInvalidAction
+		}
+		result.Invalid, err = game.withdrawnWords(tokens)
 	default:
 		return Result{}, ErrInvalidAction
 	}
 	if err != nil {
 		return Result{}, err
 	}
+	game.shareRack()
 	game.Turn.input = input
 	game.Turn.score = score
 	game.Turns = append(game.Turns, game.Turn)
 
-	err = gameDB.SaveState(game)
-	if err != nil {
-		return Result{}, err
+	if gameDB != nil {
+		err = gameDB.SaveState(game)
+		if err != nil {
+			return Result{}, err
+		}
 	}
 	game.SetNextTurn()
 
@@ -275,23 +552,21 @@ func (game *Game) ApplyTurn(input string, gameDB *GameDB) (Result, error) {
 // then validates the
 func (game *Game) SwapTiles(tiles []Tile) error {
 	player := game.CurrentPlayer()
-	if len(game.Tiles.Remaining) < len(tiles) {
+	if !game.Rules().canSwap(len(tiles), len(game.Tiles.Remaining)) {
 		return ErrNotEnoughTilesForSwap
 	}
 
 	var swapTiles []Tile
 
+	// work on a copy of the rack so a rejected swap leaves the hand untouched
+	hand := append([]Tile(nil), player.tiles...)
 	for _, tile := range tiles {
 		var found bool
-		for i, heldTile := range player.tiles {
+		for i, heldTile := range hand {
 			if tile == heldTile {
 				// add to reshuffle into tiles (also removing from hand)
 				swapTiles = append(swapTiles, heldTile)
-				if i == len(player.tiles) {
-					player.tiles = player.tiles[:i]
-				} else {
-					player.tiles = append(player.tiles[:i], player.tiles[i+1:]...)
-				}
+				hand = append(hand[:i], hand[i+1:]...)
 				found = true
 				break
 			}
@@ -301,7 +576,7 @@ func (game *Game) SwapTiles(tiles []Tile) error {
 		}
 	}
 
-	player.tiles = append(player.tiles, game.Draw(len(tiles))...)
+	player.tiles = append(hand, game.Draw(len(tiles))...)
 	game.Tiles.Return(swapTiles)
 	game.SetPlayerState(player)
 
@@ -311,30 +586,128 @@ func (game *Game) SwapTiles(tiles []Tile) error {
 // PlaceTiles denotes an attempt to play a word
 func (game *Game) PlaceTiles(place []TilePlacement) ([]Word, int, error) {
 	player := game.CurrentPlayer()
+	words, board, scoreTotal, compareWord, err := game.scorePlacement(place)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if scoreTotal > player.HighestScore() {
+		player.highestScore = scoreTotal
+		player.highestWord = compareWord
+	}
+
+	player.Update(scoreTotal, place)
+	player.tiles = append(player.tiles, game.Draw(len(place))...)
+
+	game.SetPlayerState(player)
+	game.SetBoard(board)
+	return words, scoreTotal, nil
+}
+
+// Preview scores a `place` input for the current player without playing it
+func (game *Game) Preview(input string) (Result, error) {
+	if isNotation(input) {
+		var err error
+		input, err = game.expandNotation(input)
+		if err != nil {
+			return Result{}, err
+		}
+	}
+	tokens := strings.Fields(input)
+	if len(tokens) == 0 || tokens[0] != "place" {
+		return Result{}, ErrInvalidAction
+	}
+	placements, err := game.TileSet().parseTilePlacements(tokens[1:])
+	if err != nil {
+		return Result{}, err
+	}
+	words, _, score, _, err := game.scorePlacement(placements)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Words: words, Score: score, Placed: len(placements), Action: "place"}, nil
+}
+
+// withdrawnWords checks the words of a withdrawn `place` again, returning those that are not valid
+func (game *Game) withdrawnWords(tokens []string) ([]string, error) {
+	if len(tokens) == 0 || tokens[0] != "place" {
+		return nil, ErrInvalidAction
+	}
+	placements, err := game.TileSet().parseTilePlacements(tokens[1:])
+	if err != nil {
+		return nil, err
+	}
+	_, _, _, _, err = game.scorePlacement(placements)
+	if invalid, ok := err.(ErrInvalidWords); ok {
+		return invalid.failedWords, nil
+	}
+	return nil, err
+}
+
+// LastPlacements returns the tiles placed by the most recent play, nil before the first play
+func (game Game) LastPlacements() []TilePlacement {
+	for i := len(game.Turns) - 1; i >= 0; i-- {
+		tokens := strings.Fields(game.Turns[i].input)
+		if len(tokens) > 1 && tokens[0] == "place" {
+			placements, _ := game.TileSet().parseTilePlacements(tokens[1:])
+			return placements
+		}
+	}
+	return nil
+}
+
+// BoardAt rebuilds the board as it was after the given number of turns, 0 for the empty board
+// also returns the tiles placed by the last play up to that turn
+func (game Game) BoardAt(turn int) (Board, []TilePlacement, error) {
+	if turn < 0 || turn > len(game.Turns) {
+		return Board{}, nil, ErrNoSuchTurn
+	}
+	board := game.board.Layout().NewBoard()
+	var last []TilePlacement
+	for _, t := range game.Turns[:turn] {
+		tokens := strings.Fields(t.input)
+		if len(tokens) < 2 || tokens[0] != "place" {
+			continue
+		}
+		placements, err := game.TileSet().parseTilePlacements(tokens[1:])
+		if err != nil {
+			return Board{}, nil, err
+		}
+		board = board.WithMove(Move{Placements: placements})
+		last = placements
+	}
+	return board, last, nil
+}
+
+// scorePlacement validates a placement for the current player and scores every word formed
+// returns the board with the tiles placed, leaving the game untouched
+func (game *Game) scorePlacement(place []TilePlacement) ([]Word, Board, int, string, error) {
+	player := game.CurrentPlayer()
 
-	if game.Turn.number == 1 {
-		if !touchesCenter(place) {
-			return nil, 0, ErrInvalidStart
+	// positions loaded part way through a game start past turn 1, so the board decides
+	if game.board.IsEmpty() {
+		if !touchesCenter(place, game.board.Center()) {
+			return nil, Board{}, 0, "", ErrInvalidStart
 		}
 	}
 
 	err := validateHand(player, place)
 	if err != nil {
-		return nil, 0, err
+		return nil, Board{}, 0, "", err
 	}
 
-	board := game.GetBoard()
+	board := game.board.Copy()
 	var words []Word
-	direction, start, err := validateTiles(&board, place)
+	direction, start, err := validateTiles(board, place)
 	if err != nil {
-		return nil, 0, err
+		return nil, Board{}, 0, "", err
 	}
 
 	// Find new word that is being played linearly
 	word, _ := FindWord(board, direction, start)
 	compareWord := word.String()
 
-	if len(word.String()) > 1 {
+	if len(word.Squares) > 1 {
 		words = append(words, word)
 	}
 
@@ -348,14 +721,14 @@ func (game *Game) PlaceTiles(place []TilePlacement) ([]Word, int, error) {
 	}
 
 	if len(words) == 0 {
-		return nil, 0, ErrNoValidWordsFound
+		return nil, Board{}, 0, "", ErrNoValidWordsFound
 	}
 
 	var scoreTotal int
 	var failedWords []string
 	for _, word := range words {
 		w := word.String()
-		if game.CheckWord(w) {
+		if game.Rules().isWord(game.Dictionary, word.Letters()) {
 			scoreTotal += word.ScoreWord()
 		} else {
 			failedWords = append(failedWords, w)
@@ -363,27 +736,14 @@ func (game *Game) PlaceTiles(place []TilePlacement) ([]Word, int, error) {
 	}
 
 	if len(failedWords) > 0 {
-		return nil, 0, ErrInvalidWords{failedWords}
+		return nil, Board{}, 0, "", ErrInvalidWords{failedWords}
 	}
 
 	for _, p := range place {
 		board.SetSquareUsed(p.Location)
 	}
-	if len(place) == HandSize {
-		scoreTotal += BINGO
-	}
-
-	if scoreTotal > player.HighestScore() {
-		player.highestScore = scoreTotal
-		player.highestWord = compareWord
-	}
-
-	player.Update(scoreTotal, place)
-	player.tiles = append(player.tiles, game.Draw(len(place))...)
-
-	game.SetPlayerState(player)
-	game.SetBoard(board)
-	return words, scoreTotal, nil
+	scoreTotal += game.Rules().bingo(len(place))
+	return words, board, scoreTotal, compareWord, nil
 }
 
 // FindWord takes direction and starting index and finds connected Word
@@ -396,26 +756,26 @@ func FindWord(board Board, direction string, coord Coordinate) (Word, bool) {
 
 	switch direction {
 	case "horizontal":
-		for i := y + 1; i < Size; i++ {
+		for i := y + 1; i < len(board); i++ {
 			if board[x][i].IsEmpty() {
 				break
 			}
 			word.Squares = append(word.Squares, board[x][i])
 		}
-		for i := y - 1; i > 0; i-- {
+		for i := y - 1; i >= 0; i-- {
 			if board[x][i].IsEmpty() {
 				break
 			}
 			word.Squares = append(word.Squares, board[x][i])
 		}
 	case "vertical":
-		for i := x + 1; i < Size; i++ {
+		for i := x + 1; i < len(board); i++ {
 			if board[i][y].IsEmpty() {
 				break
 			}
 			word.Squares = append(word.Squares, board[i][y])
 		}
-		for i := x - 1; i > 0; i-- {
+		for i := x - 1; i >= 0; i-- {
 			if board[i][y].IsEmpty() {
 				break
 			}
@@ -474,7 +834,7 @@ func validateHand(player Player, place []TilePlacement) error {
 // TODO refactor this to break out into some kind of sub function that handles
 // different options better func(dir string, start, finish int) error
 // returns direction, start, end, and error
-func validateTiles(board *Board, place []TilePlacement) (direction string, start Coordinate, err error) {
+func validateTiles(board Board, place []TilePlacement) (direction string, start Coordinate, err error) {
 	// verify all tiles are in bounds
 	// verify all tiles are in the same horizontal or vertical direction
 	var lastX, lastY int
@@ -491,7 +851,7 @@ func validateTiles(board *Board, place []TilePlacement) (direction string, start
 		x, y := t.Location.x, t.Location.y
 
 		// Verify requested coordinates are in range
-		if x < 0 || x >= Size || y < 0 || y >= Size {
+		if !board.onBoard(x, y) {
 			err = ErrInvalidSpace
 			return
 		}
@@ -525,6 +885,12 @@ func validateTiles(board *Board, place []TilePlacement) (direction string, start
 			// Indicates word needs to be horizontally placed
 			case y == lastY:
 				direction = "vertical"
+				if x < firstX {
+					firstX = x
+				}
+				if x > finalX {
+					finalX = x
+				}
 			default:
 				err = ErrInvalidPlacement
 				return
@@ -581,9 +947,9 @@ func validateTiles(board *Board, place []TilePlacement) (direction string, start
 	return
 }
 
-func touchesCenter(place []TilePlacement) bool {
+func touchesCenter(place []TilePlacement, center Coordinate) bool {
 	for _, p := range place {
-		if p.Location == CENTER {
+		if p.Location == center {
 			return true
 		}
 	}
@@ -0,0 +1,85 @@
+package scrabble
+
+import "testing"
+
+func TestPreviewAndLastPlacements(t *testing.T) {
+	game := newTestGame(t, "CATSDOGAEIRNTS", "CAT", "CATS")
+	tests := []struct {
+		input string
+		score int
+		err   error
+	}{
+		{"place c(h,8) a(h,9) t(h,10)", 10, nil},
+		{"place c(h,8) a(h,9) t(h,10) s(h,11)", 12, nil},
+		{"place c(h,1) a(h,2) t(h,3)", 0, ErrInvalidStart},
+		{"swap", 0, ErrInvalidAction},
+	}
+	for _, tt := range tests {
+		result, err := game.Preview(tt.input)
+		if err != tt.err || result.Score != tt.score {
+			t.Errorf("%s: got %v points and %v, want %v points and %v", tt.input, result.Score, err, tt.score, tt.err)
+		}
+	}
+	if _, err := game.Preview("place d(h,8) o(h,9) g(h,10) s(h,11)"); err == nil {
+		t.Error("a word not in the dictionary should not be previewed")
+	}
+	if boardLetter(game, 7, 7) != "" || len(game.CurrentPlayer().tiles) != HandSize || game.Turn.number != 1 {
+		t.Fatal("a preview should leave the game untouched")
+	}
+
+	if game.LastPlacements() != nil {
+		t.Error("no tiles are placed before the first play")
+	}
+	if _, err := game.ApplyTurn("place c(h,8) a(h,9) t(h,10)", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := game.ApplyTurn("swap", nil); err != nil {
+		t.Fatal(err)
+	}
+	last := game.LastPlacements()
+	if len(last) != 3 || last[0].Location != (Coordinate{7, 7}) || last[2].Location != (Coordinate{7, 9}) {
+		t.Errorf("last placements %v, want CAT from h8 after the pass", last)
+	}
+}
+
+func TestWinnerAndTies(t *testing.T) {
+	tests := []struct {
+		name   string
+		teams  []string
+		scores []int
+		winner string
+		won    []bool
+		tied   []bool
+	}{
+		{"single winner", nil, []int{30, 20}, "alice", []bool{true, false}, []bool{false, false}},
+		{"tie", nil, []int{25, 25}, "", []bool{false, false}, []bool{true, true}},
+		{"nobody scored", nil, []int{-3, -5}, "", []bool{false, false}, []bool{false, false}},
+		{"tie for the lead", nil, []int{25, 25, 10}, "", []bool{false, false, false}, []bool{true, true, false}},
+		{"team win", []string{"x", "y", "x", "y"}, []int{10, 30, 25, 0}, "", []bool{true, false, true, false}, []bool{false, false, false, false}},
+		{"team tie", []string{"x", "y", "x", "y"}, []int{10, 30, 20, 0}, "", []bool{false, false, false, false}, []bool{true, true, true, true}},
+	}
+	for _, tt := range tests {
+		var requests []PlayerRequest
+		for i := range tt.scores {
+			request := PlayerRequest{Name: []string{"alice", "bob", "carol", "dave"}[i]}
+			if tt.teams != nil {
+				request.Team = tt.teams[i]
+			}
+			requests = append(requests, request)
+		}
+		dict := NewDictionary(nil)
+		game := NewGameWithOptions(requests, GameOptions{Seed: 1, Dictionary: &dict, FixedOrder: true}, nil)
+		for i := range game.players {
+			game.players[i].score = tt.scores[i]
+		}
+
+		if winner := game.Winner(); winner.Name != tt.winner {
+			t.Errorf("%s: winner %q, want %q", tt.name, winner.Name, tt.winner)
+		}
+		for i, p := range game.players {
+			if game.Won(p) != tt.won[i] || game.Tied(p) != tt.tied[i] {
+				t.Errorf("%s: %s won %v tied %v, want won %v tied %v", tt.name, p.Name, game.Won(p), game.Tied(p), tt.won[i], tt.tied[i])
+			}
+		}
+	}
+}
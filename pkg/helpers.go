@@ -6,20 +6,10 @@ import (
 	"strings"
 )
 
-func parseTiles(tokens []string) []Tile {
-	var tiles []Tile
-	for _, t := range tokens {
-		letter := strings.ToUpper(t)
-		tiles = append(tiles, getTile(letter))
-	}
-
-	return tiles
-}
-
 // parses input tokens into a list of tiles to be placed
 // received in format of `a(1,a)`
 // special case of _a(1,a) indicates usage of blank tile
-func parseTilePlacements(tokens []string) ([]TilePlacement, error) {
+func (ts TileSet) parseTilePlacements(tokens []string) ([]TilePlacement, error) {
 	var tilePlacements []TilePlacement
 	for _, t := range tokens {
 		t = strings.Trim(t, ")")
@@ -29,7 +19,7 @@ func parseTilePlacements(tokens []string) ([]TilePlacement, error) {
 		}
 		letter := strings.ToUpper(spl[0])
 		coord := strings.Split(spl[1], ",")
-		if len(spl) != 2 {
+		if len(coord) != 2 {
 			return nil, ErrTileFormat
 		}
 		rawX := coord[0]
@@ -40,11 +30,12 @@ func parseTilePlacements(tokens []string) ([]TilePlacement, error) {
 			return nil, err
 		}
 
-		runeX := []rune(rawX)
-		x := toInt(runeX[0])
-		if y > 15 || y < 1 {
+		// rows are a single letter, squares off the board are rejected when the tiles are placed
+		runeX := []rune(strings.TrimSpace(rawX))
+		if len(runeX) != 1 || y < 1 {
 			return nil, ErrInvalidIndex
 		}
+		x := toInt(runeX[0])
 
 		if strings.HasPrefix(letter, "_") {
 			tilePlacements = append(tilePlacements, TilePlacement{
@@ -54,7 +45,7 @@ func parseTilePlacements(tokens []string) ([]TilePlacement, error) {
 		} else {
 			tilePlacements = append(tilePlacements, TilePlacement{
 				Location: Coordinate{x - 1, y - 1},
-				Tile:     getTile(letter),
+				Tile:     ts.Tile(letter),
 			})
 		}
 	}
@@ -0,0 +1,50 @@
+package scrabble
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestGame starts an in memory game of two players drawing from the bag in the order given,
+// alice is dealt the first seven tiles and moves first, only the given words are valid
+func newTestGame(t *testing.T, bag string, words ...string) *Game {
+	t.Helper()
+	tiles, err := parseSetupTiles(EnglishTiles, bag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dict := NewDictionary(words)
+	opts := GameOptions{Seed: 1, Bag: tiles, Dictionary: &dict, FixedOrder: true}
+	return NewGameWithOptions([]PlayerRequest{{Name: "alice"}, {Name: "bob"}}, opts, nil)
+}
+
+// boardLetter returns the letter on a square, written in lower case for a blank and empty for an empty square
+func boardLetter(game *Game, x, y int) string {
+	sq := game.board[x][y]
+	if sq.IsEmpty() {
+		return ""
+	}
+	if sq.Value.IsBlank {
+		return strings.ToLower(sq.Value.Letter)
+	}
+	return sq.Value.Letter
+}
+
+// newTestDB opens an empty database in a temporary directory, games and puzzles read back
+// find their word lists in the root of the repository
+func newTestDB(t *testing.T) *GameDB {
+	t.Helper()
+	database, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "game.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	gameDB := NewDB(database)
+	gameDB.dataDir = ".."
+	if err := gameDB.InitDB(); err != nil {
+		t.Fatal(err)
+	}
+	return gameDB
+}
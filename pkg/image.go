@@ -0,0 +1,231 @@
+package scrabble
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// Default width of a square in pixels when drawing a board
+const defaultSquareSize = 40
+
+// ImageOptions controls how a board is drawn
+// @SquareSize width of a square in pixels, 0 for the default
+// @Highlight placements drawn as the last play
+type ImageOptions struct {
+	SquareSize int
+	Highlight  []TilePlacement
+}
+
+// Colors of the board images
+var (
+	premiumColors = map[string]color.RGBA{
+		"TW": {0xd9, 0x4f, 0x4b, 0xff},
+		"DW": {0xf2, 0xa7, 0xb5, 0xff},
+		"TL": {0x3d, 0x7e, 0xc9, 0xff},
+		"DL": {0xa9, 0xd6, 0xef, 0xff},
+		"QW": {0x8e, 0x2a, 0x2a, 0xff},
+		"QL": {0x2c, 0x4a, 0x8c, 0xff},
+		"__": {0xe9, 0xe4, 0xd0, 0xff},
+	}
+	backgroundColor = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	gridColor       = color.RGBA{0x8a, 0x84, 0x6e, 0xff}
+	tileColor       = color.RGBA{0xf1, 0xd0, 0x8a, 0xff}
+	highlightColor  = color.RGBA{0xff, 0xe1, 0x4d, 0xff}
+	highlightBorder = color.RGBA{0xe0, 0x6c, 0x12, 0xff}
+	letterColor     = color.RGBA{0x22, 0x22, 0x22, 0xff}
+	blankColor      = color.RGBA{0xc0, 0x39, 0x2b, 0xff}
+	labelColor      = color.RGBA{0xff, 0xff, 0xff, 0xff}
+)
+
+// imageRect is a filled square of a board image, outlined when the border is set
+type imageRect struct {
+	x, y, size int
+	fill       color.RGBA
+	border     color.RGBA
+	width      int
+}
+
+// imageText is text of a board image centered on x, y
+// @size height of the capital letters in pixels
+type imageText struct {
+	x, y, size int
+	text       string
+	fill       color.RGBA
+}
+
+// boardImage lays out the board as squares and text, shared by the svg and png writers
+func (b Board) boardImage(opts ImageOptions) (int, []imageRect, []imageText) {
+	sq := opts.SquareSize
+	if sq <= 0 {
+		sq = defaultSquareSize
+	}
+	highlight := make(map[Coordinate]bool)
+	for _, p := range opts.Highlight {
+		highlight[p.Location] = true
+	}
+
+	var rects []imageRect
+	var texts []imageText
+	// the first row and column hold the coordinates
+	for i := range b {
+		texts = append(texts,
+			imageText{x: (i+1)*sq + sq/2, y: sq / 2, size: sq * 3 / 10, text: fmt.Sprint(i + 1), fill: letterColor},
+			imageText{x: sq / 2, y: (i+1)*sq + sq/2, size: sq * 3 / 10, text: strings.ToUpper(string(toRune(i + 1))), fill: letterColor},
+		)
+	}
+
+	for x, row := range b {
+		for y, s := range row {
+			left, top := (y+1)*sq, (x+1)*sq
+			cx, cy := left+sq/2, top+sq/2
+			if s.IsEmpty() {
+				rects = append(rects, imageRect{x: left, y: top, size: sq, fill: premiumColors[s.Multiplier], border: gridColor, width: 1})
+				if s.Multiplier != "__" {
+					texts = append(texts, imageText{x: cx, y: cy, size: sq * 3 / 10, text: s.Multiplier, fill: labelColor})
+				}
+				continue
+			}
+
+			rect := imageRect{x: left, y: top, size: sq, fill: tileColor, border: gridColor, width: 1}
+			if highlight[s.Coordinate] {
+				rect.fill, rect.border, rect.width = highlightColor, highlightBorder, sq/16+1
+			}
+			rects = append(rects, rect)
+
+			// blanks show their letter in a different color and no value
+			fill := letterColor
+			if s.Value.IsBlank {
+				fill = blankColor
+			}
+			texts = append(texts, imageText{x: cx, y: cy, size: sq / 2, text: s.Value.Letter, fill: fill})
+			if !s.Value.IsBlank {
+				texts = append(texts, imageText{x: left + sq*4/5, y: top + sq*4/5, size: sq / 5, text: fmt.Sprint(s.Value.Value), fill: letterColor})
+			}
+		}
+	}
+	return (len(b) + 1) * sq, rects, texts
+}
+
+// WriteSVG draws the board as an svg image
+func (b Board) WriteSVG(w io.Writer, opts ImageOptions) error {
+	size, rects, texts := b.boardImage(opts)
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" viewBox="0 0 %v %v">`+"\n", size, size, size, size)
+	fmt.Fprintf(&svg, `<rect width="%v" height="%v" fill="%s"/>`+"\n", size, size, hexColor(backgroundColor))
+	for _, r := range rects {
+		fmt.Fprintf(&svg, `<rect x="%v" y="%v" width="%v" height="%v" fill="%s" stroke="%s" stroke-width="%v"/>`+"\n",
+			r.x, r.y, r.size, r.size, hexColor(r.fill), hexColor(r.border), r.width)
+	}
+	for _, t := range texts {
+		// capital letters are about 0.7 of the font size
+		fmt.Fprintf(&svg, `<text x="%v" y="%v" font-family="sans-serif" font-weight="bold" font-size="%v" fill="%s" text-anchor="middle" dominant-baseline="central">%s</text>`+"\n",
+			t.x, t.y, t.size*10/7, hexColor(t.fill), t.text)
+	}
+	svg.WriteString("</svg>\n")
+	_, err := io.WriteString(w, svg.String())
+	return err
+}
+
+// WritePNG draws the board as a png image, text uses a built in bitmap font
+func (b Board) WritePNG(w io.Writer, opts ImageOptions) error {
+	size, rects, texts := b.boardImage(opts)
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), image.NewUniform(backgroundColor), image.Point{}, draw.Src)
+	for _, r := range rects {
+		square := image.Rect(r.x, r.y, r.x+r.size, r.y+r.size)
+		draw.Draw(img, square, image.NewUniform(r.border), image.Point{}, draw.Src)
+		draw.Draw(img, square.Inset(r.width), image.NewUniform(r.fill), image.Point{}, draw.Src)
+	}
+	for _, t := range texts {
+		drawText(img, t)
+	}
+	return png.Encode(w, img)
+}
+
+// drawText draws text with the bitmap font, scaled to the nearest whole multiple of the glyph height
+func drawText(img *image.RGBA, t imageText) {
+	scale := t.size / glyphHeight
+	if scale < 1 {
+		scale = 1
+	}
+	letters := []rune(strings.ToUpper(t.text))
+	width := len(letters)*(glyphWidth+1)*scale - scale
+	left, top := t.x-width/2, t.y-glyphHeight*scale/2
+	fill := image.NewUniform(t.fill)
+	for i, r := range letters {
+		glyph, ok := glyphs[r]
+		if !ok {
+			continue
+		}
+		x0 := left + i*(glyphWidth+1)*scale
+		for row, line := range glyph {
+			for col, c := range line {
+				if c != '#' {
+					continue
+				}
+				dot := image.Rect(x0+col*scale, top+row*scale, x0+(col+1)*scale, top+(row+1)*scale)
+				draw.Draw(img, dot, fill, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// Size of the bitmap font glyphs
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+)
+
+// glyphs of the bitmap font used for png images, one string per row
+// accented letters of the built in tile sets squeeze the letter below its mark
+var glyphs = map[rune][glyphHeight]string{
+	'A': {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B': {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C': {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D': {"####.", "#...#", "#...#", "#...#", "#...#", "#...#", "####."},
+	'E': {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F': {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G': {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H': {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I': {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J': {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K': {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L': {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M': {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N': {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O': {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P': {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q': {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R': {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S': {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T': {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U': {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V': {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W': {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X': {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y': {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	'Z': {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	'Ä': {".#.#.", ".....", ".###.", "#...#", "#####", "#...#", "#...#"},
+	'Ñ': {".##.#", "#..#.", "#...#", "##..#", "#.#.#", "#..##", "#...#"},
+	'Ö': {".#.#.", ".....", ".###.", "#...#", "#...#", "#...#", ".###."},
+	'Ü': {".#.#.", ".....", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'0': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+}
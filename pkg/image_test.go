@@ -0,0 +1,82 @@
+package scrabble
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// imageBoard returns the standard board with CAT played across from h8, the A a blank,
+// and the placements of the play
+func imageBoard() (Board, []TilePlacement) {
+	board := NewBoard()
+	a := EnglishTiles.Tile("A")
+	a.IsBlank, a.Value = true, 0
+	play := []TilePlacement{
+		{Tile: EnglishTiles.Tile("C"), Location: Coordinate{7, 7}},
+		{Tile: a, Location: Coordinate{7, 8}},
+		{Tile: EnglishTiles.Tile("T"), Location: Coordinate{7, 9}},
+	}
+	return board.WithMove(Move{Placements: play}), play
+}
+
+func TestWriteSVG(t *testing.T) {
+	board, play := imageBoard()
+	var out bytes.Buffer
+	if err := board.WriteSVG(&out, ImageOptions{SquareSize: 20, Highlight: play[:1]}); err != nil {
+		t.Fatal(err)
+	}
+	svg := out.String()
+	for _, want := range []string{
+		`width="320" height="320"`,
+		`fill="` + hexColor(premiumColors["TW"]) + `"`,
+		`fill="` + hexColor(highlightColor) + `" stroke="` + hexColor(highlightBorder) + `"`,
+		`fill="` + hexColor(blankColor) + `" text-anchor="middle" dominant-baseline="central">A</text>`,
+		`>15</text>`,
+		`>O</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg is missing %s", want)
+		}
+	}
+	// C and T show their value, the blank does not
+	if n := strings.Count(svg, ">3</text>"); n != 2 {
+		t.Errorf("%v values of 3 drawn, want 2 for the C and a label", n)
+	}
+	if n := strings.Count(svg, ">0</text>"); n != 0 {
+		t.Errorf("the blank should show no value, %v zeros drawn", n)
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	board, play := imageBoard()
+	var out bytes.Buffer
+	sq := 20
+	if err := board.WritePNG(&out, ImageOptions{SquareSize: sq, Highlight: play[:1]}); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := img.Bounds().Dx(); size != 16*sq || img.Bounds().Dy() != 16*sq {
+		t.Fatalf("image is %v, want %v pixels square", img.Bounds(), 16*sq)
+	}
+	tests := []struct {
+		name string
+		x, y int
+		want color.RGBA
+	}{
+		{"triple word in the corner", sq + 2, sq + 2, premiumColors["TW"]},
+		{"highlighted border of the C", 8*sq + 1, 8*sq + 1, highlightBorder},
+		{"tile of the T", 10*sq + 3, 8*sq + 3, tileColor},
+		{"coordinates", 1, 1, backgroundColor},
+	}
+	for _, tt := range tests {
+		if got := color.RGBAModel.Convert(img.At(tt.x, tt.y)); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package scrabble
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// Limits of board layouts, rows are named by a single letter
+const (
+	minLayoutSize = 5
+	maxLayoutSize = 25
+)
+
+// Layout represents the geometry and premium squares of a board
+// @Premiums one row of multiplier codes per row of the board, `__` for plain squares
+// the board is square with an odd size, the first play covers the middle square
+type Layout struct {
+	Name     string
+	Premiums [][]string
+}
+
+// StandardLayout is the 15x15 board
+var StandardLayout = Layout{
+	Name: "standard",
+	Premiums: [][]string{
+		{"TW", "__", "__", "DL", "__", "__", "__", "TW", "__", "__", "__", "DL", "__", "__", "TW"},
+		{"__", "DW", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "DW", "__"},
+		{"__", "__", "DW", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DW", "__", "__"},
+		{"DL", "__", "__", "DW", "__", "__", "__", "DL", "__", "__", "__", "DW", "__", "__", "DL"},
+		{"__", "__", "__", "__", "DW", "__", "__", "__", "__", "__", "DW", "__", "__", "__", "__"},
+		{"__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__"},
+		{"__", "__", "DL", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DL", "__", "__"},
+		{"TW", "__", "__", "DL", "__", "__", "__", "DW", "__", "__", "__", "DL", "__", "__", "TW"},
+		{"__", "__", "DL", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DL", "__", "__"},
+		{"__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__"},
+		{"__", "__", "__", "__", "DW", "__", "__", "__", "__", "__", "DW", "__", "__", "__", "__"},
+		{"DL", "__", "__", "DW", "__", "__", "__", "DL", "__", "__", "__", "DW", "__", "__", "DL"},
+		{"__", "__", "DW", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DW", "__", "__"},
+		{"__", "DW", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "DW", "__"},
+		{"TW", "__", "__", "DL", "__", "__", "__", "TW", "__", "__", "__", "DL", "__", "__", "TW"},
+	},
+}
+
+// SuperLayout is the 21x21 Super Scrabble board with quadruple word and letter squares
+var SuperLayout = Layout{
+	Name: "super",
+	Premiums: [][]string{
+		{"QW", "__", "__", "DL", "__", "__", "__", "TW", "__", "__", "DL", "__", "__", "TW", "__", "__", "__", "DL", "__", "__", "QW"},
+		{"__", "DW", "__", "__", "TL", "__", "__", "__", "DW", "__", "__", "__", "DW", "__", "__", "__", "TL", "__", "__", "DW", "__"},
+		{"__", "__", "DW", "__", "__", "QL", "__", "__", "__", "DW", "__", "DW", "__", "__", "__", "QL", "__", "__", "DW", "__", "__"},
+		{"DL", "__", "__", "TW", "__", "__", "DL", "__", "__", "__", "TW", "__", "__", "__", "DL", "__", "__", "TW", "__", "__", "DL"},
+		{"__", "TL", "__", "__", "DW", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "DW", "__", "__", "TL", "__"},
+		{"__", "__", "QL", "__", "__", "DW", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DW", "__", "__", "QL", "__", "__"},
+		{"__", "__", "__", "DL", "__", "__", "DW", "__", "__", "__", "DL", "__", "__", "__", "DW", "__", "__", "DL", "__", "__", "__"},
+		{"TW", "__", "__", "__", "__", "__", "__", "DW", "__", "__", "__", "__", "__", "DW", "__", "__", "__", "__", "__", "__", "TW"},
+		{"__", "DW", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "DW", "__"},
+		{"__", "__", "DW", "__", "__", "DL", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DL", "__", "__", "DW", "__", "__"},
+		{"DL", "__", "__", "TW", "__", "__", "DL", "__", "__", "__", "DW", "__", "__", "__", "DL", "__", "__", "TW", "__", "__", "DL"},
+		{"__", "__", "DW", "__", "__", "DL", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DL", "__", "__", "DW", "__", "__"},
+		{"__", "DW", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "DW", "__"},
+		{"TW", "__", "__", "__", "__", "__", "__", "DW", "__", "__", "__", "__", "__", "DW", "__", "__", "__", "__", "__", "__", "TW"},
+		{"__", "__", "__", "DL", "__", "__", "DW", "__", "__", "__", "DL", "__", "__", "__", "DW", "__", "__", "DL", "__", "__", "__"},
+		{"__", "__", "QL", "__", "__", "DW", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DW", "__", "__", "QL", "__", "__"},
+		{"__", "TL", "__", "__", "DW", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "DW", "__", "__", "TL", "__"},
+		{"DL", "__", "__", "TW", "__", "__", "DL", "__", "__", "__", "TW", "__", "__", "__", "DL", "__", "__", "TW", "__", "__", "DL"},
+		{"__", "__", "DW", "__", "__", "QL", "__", "__", "__", "DW", "__", "DW", "__", "__", "__", "QL", "__", "__", "DW", "__", "__"},
+		{"__", "DW", "__", "__", "TL", "__", "__", "__", "DW", "__", "__", "__", "DW", "__", "__", "__", "TL", "__", "__", "DW", "__"},
+		{"QW", "__", "__", "DL", "__", "__", "__", "TW", "__", "__", "DL", "__", "__", "TW", "__", "__", "__", "DL", "__", "__", "QW"},
+	},
+}
+
+// FriendsLayout is a 15x15 board in the style of Words With Friends, the middle square has no premium
+var FriendsLayout = Layout{
+	Name: "friends",
+	Premiums: [][]string{
+		{"__", "__", "__", "TW", "__", "__", "TL", "__", "TL", "__", "__", "TW", "__", "__", "__"},
+		{"__", "__", "DL", "__", "__", "DW", "__", "__", "__", "DW", "__", "__", "DL", "__", "__"},
+		{"__", "DL", "__", "__", "DL", "__", "__", "__", "__", "__", "DL", "__", "__", "DL", "__"},
+		{"TW", "__", "__", "TL", "__", "__", "__", "DW", "__", "__", "__", "TL", "__", "__", "TW"},
+		{"__", "__", "DL", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DL", "__", "__"},
+		{"__", "DW", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "DW", "__"},
+		{"TL", "__", "__", "__", "DL", "__", "__", "__", "__", "__", "DL", "__", "__", "__", "TL"},
+		{"__", "__", "__", "DW", "__", "__", "__", "__", "__", "__", "__", "DW", "__", "__", "__"},
+		{"TL", "__", "__", "__", "DL", "__", "__", "__", "__", "__", "DL", "__", "__", "__", "TL"},
+		{"__", "DW", "__", "__", "__", "TL", "__", "__", "__", "TL", "__", "__", "__", "DW", "__"},
+		{"__", "__", "DL", "__", "__", "__", "DL", "__", "DL", "__", "__", "__", "DL", "__", "__"},
+		{"TW", "__", "__", "TL", "__", "__", "__", "DW", "__", "__", "__", "TL", "__", "__", "TW"},
+		{"__", "DL", "__", "__", "DL", "__", "__", "__", "__", "__", "DL", "__", "__", "DL", "__"},
+		{"__", "__", "DL", "__", "__", "DW", "__", "__", "__", "DW", "__", "__", "DL", "__", "__"},
+		{"__", "__", "__", "TW", "__", "__", "TL", "__", "TL", "__", "__", "TW", "__", "__", "__"},
+	},
+}
+
+// Layouts maps names to the built in layouts, used for parsing player input
+var Layouts = map[string]Layout{
+	StandardLayout.Name: StandardLayout,
+	SuperLayout.Name:    SuperLayout,
+	FriendsLayout.Name:  FriendsLayout,
+}
+
+// Size returns the number of rows and columns of the layout
+func (l Layout) Size() int {
+	return len(l.Premiums)
+}
+
+// NewBoard returns an empty board with the premium squares of the layout
+func (l Layout) NewBoard() Board {
+	board := make(Board, len(l.Premiums))
+	for i, row := range l.Premiums {
+		board[i] = make([]Square, len(row))
+		for j, val := range row {
+			board[i][j] = Square{
+				Multiplier: val,
+				Coordinate: Coordinate{i, j},
+			}
+		}
+	}
+	return board
+}
+
+// FindLayout returns the built in layout with the given name, or loads a layout file
+func FindLayout(name string) (Layout, error) {
+	if layout, ok := Layouts[name]; ok {
+		return layout, nil
+	}
+	return LoadLayout(name)
+}
+
+// LoadLayout reads a layout file
+func LoadLayout(path string) (Layout, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Layout{}, err
+	}
+	defer file.Close()
+	layout, err := ParseLayout(file)
+	if err != nil {
+		return Layout{}, err
+	}
+	if layout.Name == "" {
+		layout.Name = path
+	}
+	return layout, nil
+}
+
+// ParseLayout reads a layout, one row of multiplier codes per line separated by spaces
+// codes are __ (or .), DL, TL, QL, DW, TW and QW, `name NAME` names the layout
+// lines starting with # are ignored
+func ParseLayout(r io.Reader) (Layout, error) {
+	var layout Layout
+	scanner := bufio.NewScanner(r)
+	var line int
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if fields[0] == "name" {
+			layout.Name = strings.TrimSpace(strings.TrimPrefix(text, "name"))
+			continue
+		}
+
+		var row []string
+		for _, code := range fields {
+			code = strings.ToUpper(code)
+			if code == "." {
+				code = "__"
+			}
+			_, letter := letterMult[code]
+			_, word := wordMult[code]
+			if !letter && !word {
+				return layout, ErrLayoutFormat{Line: line, Reason: "unknown square " + code}
+			}
+			row = append(row, code)
+		}
+		if len(layout.Premiums) > 0 && len(row) != len(layout.Premiums[0]) {
+			return layout, ErrLayoutFormat{Line: line, Reason: "rows must all have the same number of squares"}
+		}
+		layout.Premiums = append(layout.Premiums, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return layout, err
+	}
+
+	size := len(layout.Premiums)
+	switch {
+	case size < minLayoutSize || size > maxLayoutSize:
+		return layout, ErrLayoutFormat{Line: line, Reason: "board must have between 5 and 25 rows"}
+	case len(layout.Premiums[0]) != size:
+		return layout, ErrLayoutFormat{Line: line, Reason: "board must have as many rows as columns"}
+	case size%2 == 0:
+		return layout, ErrLayoutFormat{Line: line, Reason: "board must have an odd size so it has a middle square"}
+	}
+	return layout, nil
+}
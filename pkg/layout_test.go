@@ -0,0 +1,91 @@
+package scrabble
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLayout(t *testing.T) {
+	layout, err := ParseLayout(strings.NewReader("# a small board\nname tiny\nTW . . . tw\n. DL . DL .\n. . DW . .\n. dl . DL .\nTW . . . TW\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layout.Name != "tiny" || layout.Size() != 5 || layout.Premiums[0][4] != "TW" || layout.Premiums[2][2] != "DW" || layout.Premiums[0][1] != "__" {
+		t.Errorf("read %+v", layout)
+	}
+	board := layout.NewBoard()
+	if len(board) != 5 || board[3][1].Multiplier != "DL" || board[3][1].Coordinate != (Coordinate{3, 1}) {
+		t.Errorf("board of the layout: %v", board)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		line int
+	}{
+		{"unknown square", ". . . . .\n. XX . . .\n", 2},
+		{"uneven rows", ". . . . .\n. . . .\n", 2},
+		{"too small", ". . .\n. . .\n. . .\n", 3},
+		{"not square", ". . . . .\n. . . . .\n. . . . .\n. . . . .\n. . . . .\n. . . . .\n", 6},
+		{"even size", ". . . . . .\n. . . . . .\n. . . . . .\n. . . . . .\n. . . . . .\n. . . . . .\n", 6},
+	}
+	for _, tt := range tests {
+		_, err := ParseLayout(strings.NewReader(tt.text))
+		if format, ok := err.(ErrLayoutFormat); !ok || format.Line != tt.line {
+			t.Errorf("%s: got %v, want a format error on line %v", tt.name, err, tt.line)
+		}
+	}
+}
+
+func TestBuiltInLayoutsAreSymmetric(t *testing.T) {
+	sizes := map[string]int{"standard": 15, "super": 21, "friends": 15}
+	for name, layout := range Layouts {
+		size := layout.Size()
+		if size != sizes[name] {
+			t.Errorf("%s: size %v, want %v", name, size, sizes[name])
+		}
+		for x, row := range layout.Premiums {
+			if len(row) != size {
+				t.Fatalf("%s: row %v has %v squares", name, x, len(row))
+			}
+			for y, code := range row {
+				if code != layout.Premiums[y][x] || code != layout.Premiums[size-1-x][size-1-y] {
+					t.Errorf("%s: square %v,%v is not mirrored", name, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestSuperLayoutGame(t *testing.T) {
+	tiles := EnglishTiles.parseTiles(strings.Split("CATSDOGCATEEIR", ""))
+	dict := NewDictionary([]string{"CAT"})
+	game := NewGameWithOptions([]PlayerRequest{{Name: "alice"}, {Name: "bob"}},
+		GameOptions{Seed: 1, Bag: tiles, Dictionary: &dict, Layout: &SuperLayout, FixedOrder: true}, nil)
+	if game.Rules().Layout != "super" || len(game.GetBoard()) != 21 {
+		t.Fatalf("game is played on %s with %v rows", game.Rules().Layout, len(game.GetBoard()))
+	}
+
+	// the first play covers the middle square of the larger board
+	if _, err := game.ApplyTurn("h8 CAT", nil); err != ErrInvalidStart {
+		t.Errorf("a play through h8: got %v, want %v", err, ErrInvalidStart)
+	}
+	result, err := game.ApplyTurn("k11 CAT", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Score != 10 {
+		t.Errorf("CAT through the middle double word scored %v, want 10", result.Score)
+	}
+
+	// rows run to u and columns to 21, the corner is a quadruple word
+	if result, err := game.Preview("u19 CAT"); err != nil || result.Score != 20 {
+		t.Errorf("a play into the corner: got %v points and %v, want 20 points", result.Score, err)
+	}
+	if _, err := game.Preview("u20 CAT"); err == nil {
+		t.Error("a play off the board should be rejected")
+	}
+	if game.LastPlacements()[0].Location != (Coordinate{10, 10}) {
+		t.Errorf("last play starts at %v", game.LastPlacements()[0].Location)
+	}
+}
@@ -0,0 +1,253 @@
+package scrabble
+
+import (
+	"bufio"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default weights of the leave heuristic, in points
+const (
+	duplicatePenalty  = 3.0
+	balancePenalty    = 2.5
+	qWithoutUPenalty  = 5.0
+	tripleLanePenalty = 3.0
+	tripleLaneReach   = 7
+)
+
+// LeaveTable values the tiles kept on a rack after a play
+// @Values exact values keyed by the sorted leave, "_" for blanks (ex: "ERS_")
+// single letter entries also replace the default values used by the heuristic
+// @LanePenalty cost of every triple word square opened by a play
+// the heuristic uses the vowels and tile values of the english tiles unless the rules name another set
+type LeaveTable struct {
+	Values      map[string]float64
+	LanePenalty float64
+	tiles       TileSet
+}
+
+// DefaultLeaves evaluates leaves with the built in heuristic only
+var DefaultLeaves = LeaveTable{
+	Values:      map[string]float64{},
+	LanePenalty: tripleLanePenalty,
+}
+
+// singleLeaveValues is the value of keeping each individual english tile
+var singleLeaveValues = map[string]float64{
+	"A": 1.0,
+	"B": -2.0,
+	"C": 0.9,
+	"D": 0.5,
+	"E": 4.0,
+	"F": -2.2,
+	"G": -2.9,
+	"H": 1.1,
+	"I": -0.6,
+	"J": -1.5,
+	"K": -0.5,
+	"L": -0.2,
+	"M": 0.6,
+	"N": 0.2,
+	"O": -1.5,
+	"P": -0.5,
+	"Q": -6.8,
+	"R": 1.4,
+	"S": 7.9,
+	"T": -0.1,
+	"U": -5.1,
+	"V": -5.5,
+	"W": -3.8,
+	"X": 3.3,
+	"Y": -0.6,
+	"Z": 5.1,
+	"_": 25.6,
+}
+
+// LoadLeaveTable opens a file of leaves and their values, one `LEAVE VALUE` pair per line
+// lines starting with # are ignored, `?` may be used in place of `_` for blanks
+func LoadLeaveTable(path string) (LeaveTable, error) {
+	table := LeaveTable{
+		Values:      make(map[string]float64),
+		LanePenalty: tripleLanePenalty,
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return table, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	var line int
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return table, ErrLeaveFormat{Line: line}
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return table, ErrLeaveFormat{Line: line}
+		}
+		letters := strings.ReplaceAll(strings.ToUpper(fields[0]), "?", "_")
+		table.Values[leaveKey(EnglishTiles.parseTiles(EnglishTiles.Split(letters)))] = value
+	}
+	return table, scanner.Err()
+}
+
+// forRules returns the table evaluating leaves with the tile set of the rules
+func (lt LeaveTable) forRules(rules RuleSet) LeaveTable {
+	if ts, err := FindTileSet(rules.TileSet); err == nil {
+		lt.tiles = ts
+	}
+	return lt
+}
+
+// tileSet returns the tiles the heuristic is evaluated with, english by default
+func (lt LeaveTable) tileSet() TileSet {
+	if lt.tiles.Name == "" {
+		return EnglishTiles
+	}
+	return lt.tiles
+}
+
+// Value returns the worth of keeping the provided tiles
+// exact table entries are used when present, otherwise the heuristic
+func (lt LeaveTable) Value(leave []Tile) float64 {
+	if len(leave) == 0 {
+		return 0
+	}
+	key := leaveKey(leave)
+	if value, ok := lt.Values[key]; ok {
+		return value
+	}
+
+	ts := lt.tileSet()
+	var value float64
+	var vowelCount, consonants int
+	counts := make(map[string]int)
+	for _, t := range leave {
+		letter := tileKey(t)
+		counts[letter]++
+		if single, ok := lt.Values[letter]; ok {
+			value += single
+		} else if single, ok := ts.LeaveValues[letter]; ok {
+			value += single
+		} else if letter == "_" {
+			value += singleLeaveValues["_"]
+		}
+		switch {
+		case letter == "_":
+		case ts.Vowels[letter]:
+			vowelCount++
+		default:
+			consonants++
+		}
+	}
+
+	// duplicates limit the words a rack can form, blanks are always welcome
+	for letter, count := range counts {
+		if letter != "_" && count > 1 {
+			value -= duplicatePenalty * float64(count-1)
+		}
+	}
+
+	// a balanced leave keeps at most one more vowel than consonants or the reverse
+	imbalance := math.Abs(float64(vowelCount - consonants))
+	if imbalance > 1 {
+		value -= balancePenalty * (imbalance - 1)
+	}
+
+	if counts["Q"] > 0 && counts["U"] == 0 {
+		value -= qWithoutUPenalty
+	}
+	return value
+}
+
+// Equity values a move as its score plus its leave, minus the triple word lanes it opens
+// once the bag is empty the leave is instead the points lost by not going out
+func (lt LeaveTable) Equity(board Board, move Move, bagSize int) float64 {
+	equity := float64(move.Score)
+	if bagSize == 0 {
+		for _, t := range move.Leave {
+			equity -= 2 * float64(t.Value)
+		}
+	} else {
+		equity += lt.Value(move.Leave)
+	}
+	return equity - lt.LanePenalty*float64(opensTripleLanes(board, move))
+}
+
+// Rank sets the equity of every move and sorts them from best to worst
+func (lt LeaveTable) Rank(board Board, moves []Move, bagSize int) {
+	for i := range moves {
+		moves[i].Equity = lt.Equity(board, moves[i], bagSize)
+	}
+	sort.Sort(ByEquity(moves))
+}
+
+// Moves generates the plays of the current player ranked by equity
+func (game *Game) Moves(leaves LeaveTable) []Move {
+	moves := GenerateMovesWithRules(game.board, game.CurrentPlayer().tiles, game.Dictionary, game.Rules())
+	leaves.forRules(game.Rules()).Rank(game.board, moves, len(game.Tiles.Remaining))
+	return moves
+}
+
+// opensTripleLanes counts empty triple (or quadruple) word squares a move brings within reach
+// a square is opened when a placed tile shares its row or column with only empty
+// squares in between, and no tile could reach it along that line before the move
+func opensTripleLanes(board Board, move Move) int {
+	placed := make(map[Coordinate]bool)
+	for _, p := range move.Placements {
+		placed[p.Location] = true
+	}
+
+	var opened int
+	for x, row := range board {
+		for y, s := range row {
+			if wordMult[s.Multiplier] < 3 || !s.IsEmpty() || placed[Coordinate{x, y}] {
+				continue
+			}
+			if reachesSquare(board, placed, x, y) && !reachesSquare(board, nil, x, y) {
+				opened++
+			}
+		}
+	}
+	return opened
+}
+
+// reachesSquare walks out from a square in all four directions looking for the nearest tile
+// tiles of the move in placed count as well as tiles already on the board
+func reachesSquare(board Board, placed map[Coordinate]bool, x, y int) bool {
+	for _, d := range []Coordinate{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		for step := 1; step <= tripleLaneReach; step++ {
+			cx, cy := x+d.x*step, y+d.y*step
+			if !board.onBoard(cx, cy) {
+				break
+			}
+			if !board[cx][cy].IsEmpty() {
+				return true
+			}
+			if placed[Coordinate{cx, cy}] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// leaveKey sorts a set of tiles into the key used by leave tables
+func leaveKey(tiles []Tile) string {
+	letters := make([]string, len(tiles))
+	for i, t := range tiles {
+		letters[i] = tileKey(t)
+	}
+	sort.Strings(letters)
+	return strings.Join(letters, "")
+}
@@ -0,0 +1,109 @@
+package scrabble
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func leaveTiles(t *testing.T, letters string) []Tile {
+	t.Helper()
+	return EnglishTiles.parseTiles(strings.Split(strings.ReplaceAll(letters, "?", "_"), ""))
+}
+
+func TestLeaveValue(t *testing.T) {
+	leaves := DefaultLeaves
+	if v := leaves.Value(nil); v != 0 {
+		t.Errorf("empty leave: got %v, want 0", v)
+	}
+	if v := leaves.Value(leaveTiles(t, "S")); v != singleLeaveValues["S"] {
+		t.Errorf("S: got %v, want %v", v, singleLeaveValues["S"])
+	}
+	if v := leaves.Value(leaveTiles(t, "?")); v != singleLeaveValues["_"] {
+		t.Errorf("blank: got %v, want %v", v, singleLeaveValues["_"])
+	}
+	if e, ee := leaves.Value(leaveTiles(t, "E")), leaves.Value(leaveTiles(t, "EE")); ee >= 2*e {
+		t.Errorf("duplicates should cost: E %v, EE %v", e, ee)
+	}
+	if q, qu := leaves.Value(leaveTiles(t, "QI")), leaves.Value(leaveTiles(t, "QU")); qu-q <= 0 {
+		t.Errorf("Q is better kept with a U: QI %v, QU %v", q, qu)
+	}
+	if v := leaves.Value(leaveTiles(t, "AEIO")); v >= leaves.Value(leaveTiles(t, "AEST")) {
+		t.Errorf("vowel heavy leaves should cost: AEIO %v", v)
+	}
+
+	table := LeaveTable{Values: map[string]float64{"ERS_": 42, "Q": 1}}
+	if v := table.Value(leaveTiles(t, "S?RE")); v != 42 {
+		t.Errorf("table entry in any order: got %v, want 42", v)
+	}
+	// single letters of the table replace the heuristic values
+	if v := table.Value(leaveTiles(t, "QU")); v != 1+singleLeaveValues["U"] {
+		t.Errorf("QU with Q from the table: got %v, want %v", v, 1+singleLeaveValues["U"])
+	}
+}
+
+func TestLeaveValueGerman(t *testing.T) {
+	rules := CasualRules
+	rules.TileSet = GermanTiles.Name
+	leaves := DefaultLeaves.forRules(rules)
+	german := func(letters ...string) []Tile {
+		return GermanTiles.parseTiles(letters)
+	}
+
+	// umlauts are vowels, four vowels without a consonant are unbalanced
+	if v := leaves.Value(german("Ä", "Ö", "Ü", "E")); v != -3*balancePenalty {
+		t.Errorf("ÄÖÜE: got %v, want %v", v, -3*balancePenalty)
+	}
+	// the english tile values do not carry over, only blanks keep their worth
+	if v := leaves.Value(german("S")); v != 0 {
+		t.Errorf("S: got %v, want 0", v)
+	}
+	if v := leaves.Value(german("_")); v != singleLeaveValues["_"] {
+		t.Errorf("blank: got %v, want %v", v, singleLeaveValues["_"])
+	}
+	if english := DefaultLeaves.Value(german("Ä", "Ö", "Ü", "E")); english == leaves.Value(german("Ä", "Ö", "Ü", "E")) {
+		t.Errorf("english leaves should not treat umlauts as vowels: %v", english)
+	}
+}
+
+func TestLeaveEquity(t *testing.T) {
+	board := NewBoard()
+	move := Move{Score: 10, Leave: leaveTiles(t, "QS")}
+	leaves := LeaveTable{Values: map[string]float64{"QS": 3}}
+	if e := leaves.Equity(board, move, 20); e != 13 {
+		t.Errorf("bag not empty: got %v, want 13", e)
+	}
+	// once the bag is empty the leave is what is lost by not going out
+	if e := leaves.Equity(board, move, 0); e != 10-2*11 {
+		t.Errorf("bag empty: got %v, want %v", e, 10-2*11)
+	}
+}
+
+func TestLoadLeaveTable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "leaves")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "leaves.txt")
+	if err := ioutil.WriteFile(path, []byte("# leaves\nsre? 42.5\n\nQ -9\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	table, err := LoadLeaveTable(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.Values["ERS_"] != 42.5 || table.Values["Q"] != -9 || len(table.Values) != 2 {
+		t.Errorf("got %v", table.Values)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("ERS 1\nQ\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadLeaveTable(path); err != (ErrLeaveFormat{Line: 2}) {
+		t.Errorf("missing value: got %v", err)
+	}
+}
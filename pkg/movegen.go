@@ -0,0 +1,404 @@
+package scrabble
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Move represents a legal play found by the move generator
+// @Placements tiles placed on the board, blanks carry their assigned letter
+// @Word main word formed along the direction of the play
+// @Leave tiles remaining on the rack after the play
+// @Equity score plus the value of the leave and positional adjustments
+// @written main word as typed in notation, blanks in lower case and letters of several characters bracketed
+type Move struct {
+	Placements []TilePlacement
+	Word       string
+	Score      int
+	Leave      []Tile
+	Equity     float64
+	Direction  string
+	Start      Coordinate
+
+	written string
+}
+
+// Input formats the move as a `place` command accepted by ApplyTurn
+func (m Move) Input() string {
+	return formatPlacements(m.Placements)
+}
+
+// Notation writes the move as its starting square and main word, such as `h8 QUIXOTIC`, which is read back as the same play
+func (m Move) Notation() string {
+	word := m.written
+	if word == "" {
+		word = m.Word
+	}
+	return fmt.Sprintf("%s %s", notationSquare(m.Start, m.Direction), word)
+}
+
+func (m Move) String() string {
+	return fmt.Sprintf("%s %v points (equity %.1f) leave %v", m.Notation(), m.Score, m.Equity, m.Leave)
+}
+
+// ByScore sorts moves from highest to lowest score
+type ByScore []Move
+
+func (m ByScore) Len() int      { return len(m) }
+func (m ByScore) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+func (m ByScore) Less(i, j int) bool {
+	if m[i].Score == m[j].Score {
+		return m[i].Equity > m[j].Equity
+	}
+	return m[i].Score > m[j].Score
+}
+
+// ByEquity sorts moves from highest to lowest equity
+type ByEquity []Move
+
+func (m ByEquity) Len() int      { return len(m) }
+func (m ByEquity) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+func (m ByEquity) Less(i, j int) bool {
+	if m[i].Equity == m[j].Equity {
+		return m[i].Score > m[j].Score
+	}
+	return m[i].Equity > m[j].Equity
+}
+
+// GenerateMoves finds every legal play of the rack on the board
+// moves are scored but not ranked, see LeaveTable.Rank
+func GenerateMoves(board Board, rack []Tile, dict Dictionary) []Move {
+	return GenerateMovesWithRules(board, rack, dict, CasualRules)
+}
+
+// GenerateMovesWithRules finds every legal play of the rack, scoring bingos by the rules
+// under the clabbers rule the words played only need to be anagrams of words in the dictionary
+func GenerateMovesWithRules(board Board, rack []Tile, dict Dictionary, rules RuleSet) []Move {
+	lex := dict.index()
+	if rules.Clabbers {
+		lex = dict.anagramLexicon()
+	}
+	gen := moveGenerator{
+		board:    board,
+		lex:      lex,
+		rules:    rules,
+		rack:     make([]int, len(lex.alphabet)+1),
+		blank:    len(lex.alphabet),
+		rackSize: len(rack),
+		empty:    board.IsEmpty(),
+		cells:    make([]lineCell, board.Size()),
+	}
+	for _, t := range rack {
+		if t.Letter == "_" {
+			gen.rack[gen.blank]++
+		} else if l, ok := lex.letters[t.Letter]; ok {
+			gen.rack[l]++
+		} else {
+			// tiles that appear in no word can never be played
+			gen.unplayable = append(gen.unplayable, t)
+		}
+	}
+
+	for _, direction := range []string{"horizontal", "vertical"} {
+		gen.direction = direction
+		for line := 0; line < board.Size(); line++ {
+			gen.generateLine(line)
+		}
+	}
+	return gen.moves
+}
+
+// IsEmpty checks if no tiles have been placed on the board
+func (b Board) IsEmpty() bool {
+	for _, row := range b {
+		for _, s := range row {
+			if !s.IsEmpty() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// moveGenerator holds the state of a single move generation
+// lines are walked from every possible starting square, following the trie
+// while placing rack tiles on empty squares and reading tiles already on the board
+// @rack count of each letter of the alphabet held, blanks counted at the blank index
+type moveGenerator struct {
+	board      Board
+	lex        *lexicon
+	rules      RuleSet
+	rack       []int
+	blank      int
+	unplayable []Tile
+	rackSize   int
+	empty      bool
+	direction  string
+	line       int
+	cells      []lineCell
+	placed     []TilePlacement
+	moves      []Move
+}
+
+// lineCell is the generator view of one square along the line being searched
+// @letter alphabet index of the tile on the square, -1 when the letter forms no words
+// @cross letters allowed by the perpendicular word
+// @crossScore value of the perpendicular tiles, -1 when there are none
+type lineCell struct {
+	square     Square
+	letter     int
+	anchor     bool
+	cross      letterSet
+	crossScore int
+}
+
+// anyLetter is the cross check of a square without perpendicular tiles
+var anyLetter letterSet
+
+// letterSet holds alphabet indexes as bits, as many words as the alphabet needs, nil holds every letter
+// word lists bring their own alphabet, which may well have more than 64 letters
+type letterSet []uint64
+
+// has checks whether the letter is in the set
+func (s letterSet) has(letter int) bool {
+	if s == nil {
+		return true
+	}
+	word := letter / 64
+	return word < len(s) && s[word]&(1<<uint(letter%64)) != 0
+}
+
+// add puts the letter in the set
+func (s *letterSet) add(letter int) {
+	for letter/64 >= len(*s) {
+		*s = append(*s, 0)
+	}
+	(*s)[letter/64] |= 1 << uint(letter%64)
+}
+
+// coordinate converts a position along the current line to a board coordinate
+func (g *moveGenerator) coordinate(pos int) Coordinate {
+	if g.direction == "horizontal" {
+		return Coordinate{g.line, pos}
+	}
+	return Coordinate{pos, g.line}
+}
+
+func (g *moveGenerator) generateLine(line int) {
+	g.line = line
+	for pos := range g.cells {
+		g.cells[pos] = g.buildCell(g.coordinate(pos))
+	}
+
+	for start := range g.cells {
+		if start > 0 && !g.cells[start-1].square.IsEmpty() {
+			continue
+		}
+		if !g.anchorReachable(start) {
+			continue
+		}
+		g.extend(start, start, g.lex.root, 0, 1, 0, false)
+	}
+}
+
+// anchorReachable checks an anchor can be covered from start with the tiles in the rack
+func (g *moveGenerator) anchorReachable(start int) bool {
+	var empties int
+	for pos := start; pos < len(g.cells); pos++ {
+		if !g.cells[pos].square.IsEmpty() {
+			continue
+		}
+		empties++
+		if empties > g.rackSize {
+			return false
+		}
+		if g.cells[pos].anchor {
+			return true
+		}
+	}
+	return false
+}
+
+// buildCell finds the perpendicular constraints of a square
+func (g *moveGenerator) buildCell(c Coordinate) lineCell {
+	cell := lineCell{
+		square:     g.board[c.x][c.y],
+		cross:      anyLetter,
+		crossScore: -1,
+	}
+	if !cell.square.IsEmpty() {
+		cell.letter = -1
+		if l, ok := g.lex.letters[cell.square.Value.Letter]; ok {
+			cell.letter = l
+		}
+		return cell
+	}
+	if g.empty {
+		cell.anchor = c == g.board.Center()
+		return cell
+	}
+
+	before, after, score, found := g.crossWord(c)
+	if !found {
+		cell.anchor = g.hasNeighbor(c)
+		return cell
+	}
+	cell.anchor = true
+	cell.crossScore = score
+	cell.cross = letterSet{}
+	// letters are allowed when the tiles before, the letter and the tiles after spell a word
+	if node := g.lex.root.follow(g.lex, before); node != nil {
+		for _, e := range g.lex.edges(node) {
+			if end := e.node.follow(g.lex, after); end != nil && end.terminal {
+				cell.cross.add(e.letter)
+			}
+		}
+	}
+	return cell
+}
+
+// crossWord collects the tiles touching a square perpendicular to the play
+func (g *moveGenerator) crossWord(c Coordinate) (before, after []string, score int, found bool) {
+	dx, dy := 1, 0
+	if g.direction == "vertical" {
+		dx, dy = 0, 1
+	}
+	for x, y := c.x-dx, c.y-dy; x >= 0 && y >= 0 && !g.board[x][y].IsEmpty(); x, y = x-dx, y-dy {
+		before = append([]string{g.board[x][y].Value.Letter}, before...)
+		score += g.board[x][y].Value.Value
+		found = true
+	}
+	for x, y := c.x+dx, c.y+dy; g.board.onBoard(x, y) && !g.board[x][y].IsEmpty(); x, y = x+dx, y+dy {
+		after = append(after, g.board[x][y].Value.Letter)
+		score += g.board[x][y].Value.Value
+		found = true
+	}
+	return
+}
+
+func (g *moveGenerator) hasNeighbor(c Coordinate) bool {
+	for _, n := range []Coordinate{{c.x - 1, c.y}, {c.x + 1, c.y}, {c.x, c.y - 1}, {c.x, c.y + 1}} {
+		if !g.board.onBoard(n.x, n.y) {
+			continue
+		}
+		if !g.board[n.x][n.y].IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// extend walks the line from pos, following the trie from node
+// mainScore and wordMult accumulate the main word, crossTotal the perpendicular words
+func (g *moveGenerator) extend(start, pos int, node *trieNode, mainScore, wordMult, crossTotal int, anchored bool) {
+	if pos < len(g.cells) && !g.cells[pos].square.IsEmpty() {
+		next := g.lex.child(node, g.cells[pos].letter)
+		if next == nil {
+			return
+		}
+		g.extend(start, pos+1, next, mainScore+g.cells[pos].square.Value.Value, wordMult, crossTotal, anchored)
+		return
+	}
+
+	// the square at pos is empty (or off the board) so the word could end here
+	if node.terminal && anchored && pos-start > 1 {
+		g.record(start, pos, mainScore*wordMult+crossTotal)
+	}
+	if pos >= len(g.cells) || len(g.placed) == g.rackSize {
+		return
+	}
+
+	cell := &g.cells[pos]
+	lm, wm := premiums(cell.square)
+	for _, e := range g.lex.edges(node) {
+		if !cell.cross.has(e.letter) {
+			continue
+		}
+		for _, held := range []int{e.letter, g.blank} {
+			if g.rack[held] == 0 {
+				continue
+			}
+			g.rack[held]--
+			tile := g.lex.tiles.Tile(g.lex.alphabet[e.letter])
+			if held == g.blank {
+				tile = Tile{Letter: tile.Letter, Value: 0, IsBlank: true}
+			}
+
+			cross := crossTotal
+			if cell.crossScore >= 0 {
+				cross += (cell.crossScore + tile.Value*lm) * wm
+			}
+			g.placed = append(g.placed, TilePlacement{Location: g.coordinate(pos), Tile: tile})
+			g.extend(start, pos+1, e.node, mainScore+tile.Value*lm, wordMult*wm, cross, anchored || cell.anchor)
+			g.placed = g.placed[:len(g.placed)-1]
+			g.rack[held]++
+		}
+	}
+}
+
+// record adds the current placements as a move spanning [start, end)
+func (g *moveGenerator) record(start, end, score int) {
+	// single tiles forming words both ways are only recorded horizontally
+	if g.direction == "vertical" && len(g.placed) == 1 {
+		c := g.placed[0].Location
+		if (c.y > 0 && !g.board[c.x][c.y-1].IsEmpty()) || (c.y < len(g.board)-1 && !g.board[c.x][c.y+1].IsEmpty()) {
+			return
+		}
+	}
+	score += g.rules.bingo(len(g.placed))
+
+	var word, written strings.Builder
+	next := 0
+	for pos := start; pos < end; pos++ {
+		tile := g.cells[pos].square.Value
+		if g.cells[pos].square.IsEmpty() {
+			tile = g.placed[next].Tile
+			next++
+		}
+		word.WriteString(tile.Letter)
+		if tile.IsBlank {
+			written.WriteString(writtenLetter(strings.ToLower(tile.Letter)))
+		} else {
+			written.WriteString(writtenLetter(tile.Letter))
+		}
+	}
+
+	placements := make([]TilePlacement, len(g.placed))
+	copy(placements, g.placed)
+	leave := make([]Tile, 0, g.rackSize-len(g.placed))
+	for l, count := range g.rack {
+		for i := 0; i < count; i++ {
+			if l == g.blank {
+				leave = append(leave, g.lex.tiles.Tile("_"))
+			} else {
+				leave = append(leave, g.lex.tiles.Tile(g.lex.alphabet[l]))
+			}
+		}
+	}
+	leave = append(leave, g.unplayable...)
+
+	g.moves = append(g.moves, Move{
+		Placements: placements,
+		Word:       word.String(),
+		Score:      score,
+		Leave:      leave,
+		Equity:     float64(score),
+		Direction:  g.direction,
+		Start:      g.coordinate(start),
+		written:    written.String(),
+	})
+}
+
+// premiums returns the letter and word multiplier of an unused square
+func premiums(s Square) (int, int) {
+	if s.Used {
+		return 1, 1
+	}
+	if mult, ok := letterMult[s.Multiplier]; ok {
+		return mult, 1
+	}
+	if mult, ok := wordMult[s.Multiplier]; ok {
+		return 1, mult
+	}
+	return 1, 1
+}
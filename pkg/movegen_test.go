@@ -0,0 +1,209 @@
+package scrabble
+
+import (
+	"fmt"
+	"testing"
+)
+
+// checkMovesAgainstPreview generates the moves of the player to move and scores each one as a played turn would
+func checkMovesAgainstPreview(t *testing.T, game *Game) []Move {
+	t.Helper()
+	moves := game.Moves(DefaultLeaves)
+	for _, m := range moves {
+		result, err := game.Preview(m.Input())
+		if err != nil {
+			t.Errorf("%v: %v", m, err)
+			continue
+		}
+		if result.Score != m.Score {
+			t.Errorf("%v: generated %v points, played for %v", m, m.Score, result.Score)
+		}
+	}
+	return moves
+}
+
+func TestMovesScoreLikePlayedTurns(t *testing.T) {
+	dict, err := LoadDictionary("../" + dictPath)
+	if err != nil {
+		t.Skipf("dictionary not available: %v", err)
+	}
+	var checked int
+	for seed := int64(1); seed <= 3; seed++ {
+		game := NewGameWithOptions([]PlayerRequest{{Name: "alice"}, {Name: "bob"}}, GameOptions{Seed: seed, Dictionary: &dict}, nil)
+		for turn := 0; turn < 12 && !game.IsOver(); turn++ {
+			moves := checkMovesAgainstPreview(t, game)
+			checked += len(moves)
+			input := "swap"
+			if len(moves) > 0 {
+				input = moves[0].Input()
+			}
+			if _, err := game.ApplyTurn(input, nil); err != nil {
+				t.Fatalf("seed %v turn %v: %s: %v", seed, turn, input, err)
+			}
+		}
+	}
+	if checked == 0 {
+		t.Error("no moves were generated")
+	}
+}
+
+// findMoves returns the moves placing exactly the given tiles, keyed as letter(x,y)
+func findMoves(moves []Move, placed ...string) []Move {
+	var found []Move
+	for _, m := range moves {
+		if len(m.Placements) != len(placed) {
+			continue
+		}
+		match := true
+		for i, p := range m.Placements {
+			letter := p.Tile.Letter
+			if p.Tile.IsBlank {
+				letter = "_" + letter
+			}
+			if fmt.Sprintf("%s(%v,%v)", letter, p.Location.x, p.Location.y) != placed[i] {
+				match = false
+			}
+		}
+		if match {
+			found = append(found, m)
+		}
+	}
+	return found
+}
+
+func TestMovesWithBlank(t *testing.T) {
+	board, err := SetupBoard(StandardLayout, EnglishTiles, nil, []string{"h7 CAT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moves := GenerateMoves(board, []Tile{EnglishTiles.Tile("_")}, NewDictionary([]string{"CAT", "CATS", "SCAT"}))
+	// the blank scores nothing, the tiles already on the board keep their values
+	cats := findMoves(moves, "_S(7,9)")
+	scat := findMoves(moves, "_S(7,5)")
+	if len(cats) != 1 || cats[0].Score != 5 || cats[0].Word != "CATS" {
+		t.Errorf("CATS with a blank: got %v", cats)
+	}
+	if len(scat) != 1 || scat[0].Score != 5 || scat[0].Word != "SCAT" {
+		t.Errorf("SCAT with a blank: got %v", scat)
+	}
+	if len(moves) != 2 {
+		t.Errorf("got %v moves, want 2: %v", len(moves), moves)
+	}
+}
+
+func TestMovesWithMultiLetterTiles(t *testing.T) {
+	tiles, err := parseSetupTiles(SpanishTiles, "[CH]ICOLAE[LL]AMA[RR]OÑNEIUSDTB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dict := NewDictionary([]string{"CHICO", "CHICA", "HOLA", "LLAMA", "PERRO", "AÑO", "CALLE"})
+	dict.tiles = &SpanishTiles
+	opts := GameOptions{Seed: 1, Bag: tiles, Dictionary: &dict, TileSet: &SpanishTiles, FixedOrder: true}
+	game := NewGameWithOptions([]PlayerRequest{{Name: "alice"}, {Name: "bob"}}, opts, nil)
+
+	moves := checkMovesAgainstPreview(t, game)
+	var chico int
+	for _, m := range moves {
+		if m.Word == "CHICO" {
+			chico++
+			if len(m.Placements) != 4 {
+				t.Errorf("%v: CHICO places CH, I, C and O, got %v tiles", m, len(m.Placements))
+			}
+		}
+		// C and H tiles never stand in for CH, and the rack has no H
+		if m.Word == "HOLA" {
+			t.Errorf("%v: played without an H tile", m)
+		}
+	}
+	if chico == 0 {
+		t.Error("CHICO was not generated")
+	}
+	if _, err := game.ApplyTurn("h8 CHICO", nil); err != nil {
+		t.Fatal(err)
+	}
+	checkMovesAgainstPreview(t, game)
+}
+
+func TestSingleTileFormingTwoWords(t *testing.T) {
+	// A on the middle square and T to the upper right, an A above the middle square forms AT across and AA down
+	board, err := SetupBoard(StandardLayout, EnglishTiles, nil, []string{"h8 A", "g9 T"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moves := GenerateMoves(board, []Tile{EnglishTiles.Tile("A")}, NewDictionary([]string{"AT", "AA"}))
+	found := findMoves(moves, "A(6,7)")
+	if len(found) != 1 {
+		t.Fatalf("got %v moves placing A above the middle square, want it once: %v", len(found), moves)
+	}
+	if found[0].Score != 4 {
+		t.Errorf("%v: want 4 points for AT and AA", found[0])
+	}
+}
+
+func TestBingoScore(t *testing.T) {
+	rack, err := parseSetupTiles(EnglishTiles, "RETAINS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := StandardLayout.NewBoard()
+	moves := GenerateMoves(board, rack, NewDictionary([]string{"RETAINS"}))
+	found := findMoves(moves, "R(7,7)", "E(7,8)", "T(7,9)", "A(7,10)", "I(7,11)", "N(7,12)", "S(7,13)")
+	// 7 letters with the I on a double letter, doubled by the middle square, and the 50 point bingo
+	if len(found) != 1 || found[0].Score != 66 {
+		t.Errorf("RETAINS from the middle square: got %v", found)
+	}
+	for _, m := range moves {
+		if m.Score < 50 {
+			t.Errorf("%v: a bingo scores at least 50", m)
+		}
+	}
+
+	friends := FriendsRules
+	moves = GenerateMovesWithRules(board, rack, NewDictionary([]string{"RETAINS"}), friends)
+	for _, m := range moves {
+		if m.Score < friends.BingoBonus {
+			t.Errorf("%v: a bingo under the friends rules scores at least %v", m, friends.BingoBonus)
+		}
+	}
+}
+
+func TestCrossChecksBeyond64Letters(t *testing.T) {
+	// an alphabet of 70 letters, each forming a word under Q
+	var words []string
+	var last string
+	for i := 0; i < 70; i++ {
+		last = string(rune('一' + i))
+		words = append(words, "Q"+last)
+	}
+	board, err := SetupBoard(StandardLayout, EnglishTiles, nil, []string{"h8 Q"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	moves := GenerateMoves(board, []Tile{{Letter: last}}, NewDictionary(words))
+	if found := findMoves(moves, last+"(8,7)"); len(found) != 1 {
+		t.Errorf("the 70th letter under Q: got %v", moves)
+	}
+	if found := findMoves(moves, last+"(7,8)"); len(found) != 1 {
+		t.Errorf("the 70th letter after Q: got %v", moves)
+	}
+	if len(moves) != 2 {
+		t.Errorf("got %v moves, want one after and one under Q: %v", len(moves), moves)
+	}
+}
+
+func TestLetterSet(t *testing.T) {
+	var all letterSet
+	if !all.has(0) || !all.has(200) {
+		t.Error("a nil set holds every letter")
+	}
+	set := letterSet{}
+	for _, l := range []int{0, 63, 64, 130} {
+		set.add(l)
+	}
+	for l := 0; l < 200; l++ {
+		want := l == 0 || l == 63 || l == 64 || l == 130
+		if set.has(l) != want {
+			t.Errorf("letter %v: has %v, want %v", l, set.has(l), want)
+		}
+	}
+}
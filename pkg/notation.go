@@ -0,0 +1,188 @@
+package scrabble
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// isNotation checks whether an input is a play written as a starting square and a word
+// such as `h8 QUIXOTIC`, optionally preceded by `place`
+func isNotation(input string) bool {
+	tokens := strings.Fields(input)
+	if len(tokens) > 0 && tokens[0] == "place" {
+		tokens = tokens[1:]
+	}
+	if len(tokens) != 2 || strings.Contains(tokens[0], "(") {
+		return false
+	}
+	square := []rune(tokens[0])
+	return len(square) >= 2 && (unicode.IsDigit(square[0]) || unicode.IsDigit(square[1]))
+}
+
+// expandNotation converts a play written as a starting square and a word into a `place` input
+// squares use the labels of the board, the row first plays across (h8) and the column first plays down (8h)
+// upper case letters are tiles from the rack, lower case letters are blanks, and letters
+// already on the board are given in parentheses or as `.`
+func (game *Game) expandNotation(input string) (string, error) {
+	placements, err := parseNotation(game.board, game.TileSet(), input)
+	if err != nil {
+		return "", err
+	}
+	return formatPlacements(placements), nil
+}
+
+// parseNotation works out the tiles placed by a play written in notation
+// letters of the tile set written with several characters (CH, LL) fill a single square
+func parseNotation(board Board, ts TileSet, input string) ([]TilePlacement, error) {
+	pos := 0
+	next := func() (string, int) {
+		for pos < len(input) && input[pos] == ' ' {
+			pos++
+		}
+		start := pos
+		for pos < len(input) && input[pos] != ' ' {
+			pos++
+		}
+		return input[start:pos], start
+	}
+
+	square, squareAt := next()
+	if square == "place" {
+		square, squareAt = next()
+	}
+	word, wordAt := next()
+	if extra, extraAt := next(); extra != "" {
+		return nil, ErrNotation{Input: input, Position: extraAt, Reason: "unexpected input after the word"}
+	}
+	if word == "" {
+		return nil, ErrNotation{Input: input, Position: len(input), Reason: "missing word"}
+	}
+
+	x, y, across, err := parseSquare(input, square, squareAt, board.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	var placements []TilePlacement
+	var inParens bool
+	offset := wordAt
+	for _, text := range ts.splitInput(word) {
+		at := offset
+		offset += len(text)
+		text = unbracket(text)
+		r, _ := utf8.DecodeRuneInString(text)
+		switch {
+		case r == '(' && !inParens:
+			inParens = true
+			continue
+		case r == ')' && inParens:
+			inParens = false
+			continue
+		case r != '.' && !unicode.IsLetter(r):
+			return nil, ErrNotation{Input: input, Position: at, Reason: "expected a letter, `.` or parentheses"}
+		}
+
+		if !board.onBoard(x, y) {
+			return nil, ErrNotation{Input: input, Position: at, Reason: "word runs off the board"}
+		}
+		sq := board[x][y]
+		letter := strings.ToUpper(text)
+		switch {
+		case r == '.' || inParens:
+			if sq.IsEmpty() {
+				return nil, ErrNotation{Input: input, Position: at, Reason: "no tile on the board here"}
+			}
+			if r != '.' && sq.Value.Letter != letter {
+				return nil, ErrNotation{Input: input, Position: at, Reason: "the board has " + sq.Value.Letter + " here"}
+			}
+		case !sq.IsEmpty():
+			// a letter matching the board is played through, as if given in parentheses
+			if sq.Value.Letter != letter {
+				return nil, ErrNotation{Input: input, Position: at, Reason: "square is already taken by " + sq.Value.Letter}
+			}
+		case unicode.IsLower(r):
+			placements = append(placements, TilePlacement{
+				Location: Coordinate{x, y},
+				Tile:     Tile{Letter: letter, Value: 0, IsBlank: true},
+			})
+		default:
+			placements = append(placements, TilePlacement{
+				Location: Coordinate{x, y},
+				Tile:     ts.Tile(letter),
+			})
+		}
+
+		if across {
+			y++
+		} else {
+			x++
+		}
+	}
+	if inParens {
+		return nil, ErrNotation{Input: input, Position: wordAt + len(word), Reason: "missing `)`"}
+	}
+	if len(placements) == 0 {
+		return nil, ErrNotation{Input: input, Position: wordAt, Reason: "word places no tiles"}
+	}
+	return placements, nil
+}
+
+// parseSquare reads the starting square of a play with the labels of the board, the letter names the row
+// and the number the column, h8 (row first) plays across and 8h (column first) plays down
+func parseSquare(input, square string, at, size int) (int, int, bool, error) {
+	lower := strings.ToLower(square)
+	across := lower != "" && lower[0] >= 'a' && lower[0] <= 'z'
+
+	var row byte
+	var digits string
+	var rowAt, colAt int
+	if across {
+		row, digits = lower[0], lower[1:]
+		rowAt, colAt = at, at+1
+	} else {
+		end := strings.IndexFunc(lower, func(r rune) bool { return !unicode.IsDigit(r) })
+		if end < 0 {
+			return 0, 0, false, ErrNotation{Input: input, Position: at + len(square), Reason: "missing row letter"}
+		}
+		digits = lower[:end]
+		if end+1 != len(lower) {
+			return 0, 0, false, ErrNotation{Input: input, Position: at + end + 1, Reason: "square must be a column number and a row letter"}
+		}
+		row = lower[end]
+		rowAt, colAt = at+end, at
+	}
+
+	col, err := strconv.Atoi(digits)
+	if err != nil || col < 1 || col > size {
+		return 0, 0, false, ErrNotation{Input: input, Position: colAt, Reason: "column must be a number from 1 to " + strconv.Itoa(size)}
+	}
+	x := toInt(rune(row)) - 1
+	if x < 0 || x >= size {
+		return 0, 0, false, ErrNotation{Input: input, Position: rowAt, Reason: "row must be a letter from a to " + string(toRune(size))}
+	}
+	return x, col - 1, across, nil
+}
+
+// notationSquare names the starting square of a play as parseSquare reads it
+func notationSquare(start Coordinate, direction string) string {
+	if direction == "vertical" {
+		return fmt.Sprintf("%v%s", start.y+1, string(toRune(start.x+1)))
+	}
+	return fmt.Sprintf("%s%v", string(toRune(start.x+1)), start.y+1)
+}
+
+// formatPlacements writes placements as a `place` input accepted by ApplyTurn
+func formatPlacements(placements []TilePlacement) string {
+	tokens := []string{"place"}
+	for _, p := range placements {
+		letter := strings.ToLower(p.Tile.Letter)
+		if p.Tile.IsBlank {
+			letter = "_" + letter
+		}
+		tokens = append(tokens, fmt.Sprintf("%s(%s,%v)", letter, string(toRune(p.Location.x+1)), p.Location.y+1))
+	}
+	return strings.Join(tokens, " ")
+}
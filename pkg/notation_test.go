@@ -0,0 +1,91 @@
+package scrabble
+
+import "testing"
+
+func TestParseSquare(t *testing.T) {
+	tests := []struct {
+		square string
+		x, y   int
+		across bool
+	}{
+		{"h8", 7, 7, true},
+		{"8h", 7, 7, false},
+		{"h4", 7, 3, true},
+		{"4h", 7, 3, false},
+		{"a1", 0, 0, true},
+		{"1o", 14, 0, false},
+		{"O15", 14, 14, true},
+	}
+	for _, tt := range tests {
+		x, y, across, err := parseSquare(tt.square, tt.square, 0, 15)
+		if err != nil {
+			t.Errorf("%s: %v", tt.square, err)
+			continue
+		}
+		if x != tt.x || y != tt.y || across != tt.across {
+			t.Errorf("%s: got (%v,%v) across %v, want (%v,%v) across %v", tt.square, x, y, across, tt.x, tt.y, tt.across)
+		}
+	}
+
+	for _, square := range []string{"a16", "16a", "a0", "p8", "8p", "8", "h", "h8h"} {
+		if _, _, _, err := parseSquare(square, square, 0, 15); err == nil {
+			t.Errorf("%s: expected an error", square)
+		}
+	}
+}
+
+func TestNotationStandardOpenings(t *testing.T) {
+	tests := []struct {
+		input   string
+		squares [][2]int
+	}{
+		// row h from column 6 across, through the middle square
+		{"h6 CATS", [][2]int{{7, 5}, {7, 6}, {7, 7}, {7, 8}}},
+		// column 8 from row e down, through the middle square
+		{"8e CATS", [][2]int{{4, 7}, {5, 7}, {6, 7}, {7, 7}}},
+	}
+	for _, tt := range tests {
+		game := newTestGame(t, "CATSEEEEEEEEEE", "CATS")
+		result, err := game.ApplyTurn(tt.input, nil)
+		if err != nil {
+			t.Errorf("%s: %v", tt.input, err)
+			continue
+		}
+		// the middle square doubles the word
+		if result.Score != 12 {
+			t.Errorf("%s: scored %v, want 12", tt.input, result.Score)
+		}
+		for i, sq := range tt.squares {
+			if letter := boardLetter(game, sq[0], sq[1]); letter != string("CATS"[i]) {
+				t.Errorf("%s: square (%v,%v) has %q, want %c", tt.input, sq[0], sq[1], letter, "CATS"[i])
+			}
+		}
+	}
+
+	// the same word down column 6 from row h misses the middle square
+	game := newTestGame(t, "CATSEEEEEEEEEE", "CATS")
+	if _, err := game.ApplyTurn("6h CATS", nil); err != ErrInvalidStart {
+		t.Errorf("6h CATS: got %v, want %v", err, ErrInvalidStart)
+	}
+}
+
+func TestMoveNotationRoundTrip(t *testing.T) {
+	game := newTestGame(t, "CATSEEESCA?EEEEEEEE", "CATS", "SCAT")
+	if _, err := game.ApplyTurn("h6 CATS", nil); err != nil {
+		t.Fatal(err)
+	}
+	moves := game.Moves(DefaultLeaves)
+	if len(moves) == 0 {
+		t.Fatal("no moves found")
+	}
+	for _, m := range moves {
+		placements, err := parseNotation(game.board, game.TileSet(), m.Notation())
+		if err != nil {
+			t.Errorf("%s: %v", m.Notation(), err)
+			continue
+		}
+		if !samePlacements(placements, m.Placements) {
+			t.Errorf("%s: read back as %s, want %s", m.Notation(), formatPlacements(placements), m.Input())
+		}
+	}
+}
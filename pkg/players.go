@@ -1,8 +1,13 @@
 package scrabble
 
+// PlayerRequest represents a player joining a new game
+// @Team name of the player's team, partners share a score, empty when not playing in teams
 type PlayerRequest struct {
 	Name         string
 	UsePlainText bool
+	Bot          BotLevel
+	Engine       string
+	Team         string
 }
 
 // Player represents an active participant
@@ -16,6 +21,12 @@ type Player struct {
 	highestScore int
 	highestWord  string
 	UsePlainText bool
+	// Bot is the level of the computer playing this seat, empty for humans
+	Bot BotLevel
+	// Engine is the command of an external engine playing this seat, empty when not used
+	Engine string
+	// Team is the name of the player's team, empty when not playing in teams
+	Team string
 	//TODO add metadata
 }
 
@@ -27,7 +38,8 @@ func (p *Player) Update(addScore int, place []TilePlacement) {
 		var found bool
 		blankID := -1
 		for i, t := range p.tiles {
-			if t.IsBlank {
+			// blanks in hand are held as "_" until they are placed
+			if t.IsBlank || t.Letter == "_" {
 				blankID = i
 			}
 			if pl.Tile == t {
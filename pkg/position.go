@@ -0,0 +1,133 @@
+package scrabble
+
+// Position represents everything needed to analyze a turn outside of a running game
+// @Racks the rack of every player in turn order, only the rack to move needs to be known
+// @Unseen tiles not visible to the player to move (bag plus opponents racks)
+// @BagSize number of those unseen tiles still in the bag
+// @Rules rack size and bingo bonus of the game, the casual rules when unset
+// @Teams team of every player in turn order, nil when every player plays for themselves
+type Position struct {
+	Board      Board
+	Racks      [][]Tile
+	Scores     []int
+	ToMove     int
+	Unseen     []Tile
+	BagSize    int
+	Dictionary Dictionary
+	Rules      RuleSet
+	Teams      []string
+}
+
+// Position captures the current state of the game from the view of the current player
+func (game *Game) Position() Position {
+	current := game.CurrentPlayer()
+	pos := Position{
+		Board:      game.board,
+		Dictionary: game.Dictionary,
+		BagSize:    len(game.Tiles.Remaining),
+		Rules:      game.Rules(),
+		Teams:      game.playerTeams(),
+	}
+	for i, p := range game.players {
+		tiles := p.tiles
+		if p.id == current.id {
+			pos.ToMove = i
+			tiles = current.tiles
+		}
+		pos.Racks = append(pos.Racks, append([]Tile(nil), tiles...))
+		pos.Scores = append(pos.Scores, p.score)
+	}
+	pos.Unseen = CountUnseen(game.board, current.tiles, game.TileSet()).Tiles()
+	return pos
+}
+
+// Rack returns the tiles of the player to move
+func (pos Position) Rack() []Tile {
+	return pos.Racks[pos.ToMove]
+}
+
+// partners checks whether two players play on the same side, a player is always their own partner
+func (pos Position) partners(a, b int) bool {
+	return a == b || (pos.Teams != nil && pos.Teams[a] == pos.Teams[b])
+}
+
+// sharesRack checks whether a player plays from the rack of another, as partners do under the shared rack rule
+func (pos Position) sharesRack(a, b int) bool {
+	return a == b || (pos.Rules.SharedRack && pos.partners(a, b))
+}
+
+// side returns the first player in turn order of the side a player plays for
+// every player is their own side unless the players are split into teams
+func (pos Position) side(player int) int {
+	for i := 0; i < player; i++ {
+		if pos.partners(i, player) {
+			return i
+		}
+	}
+	return player
+}
+
+// sideScores adds up the scores of every side, keyed by side
+func (pos Position) sideScores(scores []int) map[int]int {
+	sides := make(map[int]int)
+	for i, score := range scores {
+		sides[pos.side(i)] += score
+	}
+	return sides
+}
+
+// endAdjustment applies the end of game scoring to a set of scores, by team in a team game
+func (pos Position) endAdjustment(scores []int, racks [][]Tile, out int) {
+	if pos.Teams != nil {
+		endTeamAdjustment(scores, racks, pos.Teams, pos.Rules.SharedRack, out)
+		return
+	}
+	endRackAdjustment(scores, racks, out)
+}
+
+// Moves generates the plays of the player to move ranked by equity
+func (pos Position) Moves(leaves LeaveTable) []Move {
+	moves := pos.generate(pos.Board, pos.Rack())
+	leaves.forRules(pos.Rules).Rank(pos.Board, moves, pos.BagSize)
+	return moves
+}
+
+// generate finds the plays of a rack on a board under the rules of the position
+func (pos Position) generate(board Board, rack []Tile) []Move {
+	return GenerateMovesWithRules(board, rack, pos.Dictionary, pos.Rules.orDefault())
+}
+
+// WithMove returns a copy of the board with the tiles of the move placed
+// premium squares under the new tiles are marked as used
+func (b Board) WithMove(move Move) Board {
+	b = b.Copy()
+	for _, p := range move.Placements {
+		b[p.Location.x][p.Location.y].Value = p.Tile
+		b[p.Location.x][p.Location.y].Used = true
+	}
+	return b
+}
+
+// rackValue sums the value of the tiles left on a rack
+func rackValue(rack []Tile) int {
+	var total int
+	for _, t := range rack {
+		total += t.Value
+	}
+	return total
+}
+
+// endRackAdjustment applies the end of game scoring to a set of scores
+// every player loses the value of their rack, a player who went out gains the total
+// out is the index of the player who went out, -1 when the game ended without one
+func endRackAdjustment(scores []int, racks [][]Tile, out int) {
+	var total int
+	for i, rack := range racks {
+		value := rackValue(rack)
+		scores[i] -= value
+		total += value
+	}
+	if out >= 0 {
+		scores[out] += total
+	}
+}
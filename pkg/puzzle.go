@@ -0,0 +1,195 @@
+package scrabble
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// default thresholds for a turn to make a puzzle
+const (
+	defaultPuzzleScore  = 30
+	defaultPuzzleMissed = 20
+)
+
+// PuzzleOptions controls which turns of a game become puzzles
+// @MinScore fewest points the top play must score, 0 for the default
+// @MinMissed fewest points the play made must fall short of the top play, 0 for the default
+type PuzzleOptions struct {
+	MinScore  int
+	MinMissed int
+}
+
+// Puzzle represents a position where the solver has to find the top scoring play
+// @GameID, @Turn the game and turn the position was taken from
+// @Board the board before the turn, @Rack the tiles of the player to move
+// @Answer the top scoring play found by the move generator, any play scoring as much solves the puzzle
+// @Missed the play made in the game
+type Puzzle struct {
+	ID         int64      `json:"id"`
+	GameID     int64      `json:"game_id"`
+	Turn       int        `json:"turn"`
+	Player     string     `json:"player"`
+	Board      Board      `json:"-"`
+	Rack       []Tile     `json:"-"`
+	Rules      RuleSet    `json:"rules"`
+	Answer     PlayReport `json:"answer"`
+	Missed     PlayReport `json:"missed"`
+	dictionary Dictionary
+}
+
+func (p Puzzle) String() string {
+	return fmt.Sprintf("Puzzle %v (game %v, turn %v)\n%s\nRack: %s\nFind the top scoring play",
+		p.ID, p.GameID, p.Turn, p.Board, leaveKey(p.Rack))
+}
+
+// PuzzleAttempt represents the answer a player gave to a puzzle
+// @Solved the play scored as much as the top play
+type PuzzleAttempt struct {
+	PuzzleID int64     `json:"puzzle_id"`
+	Input    string    `json:"input"`
+	Play     string    `json:"play"`
+	Score    int       `json:"score"`
+	Solved   bool      `json:"solved"`
+	Time     time.Time `json:"time"`
+}
+
+// PuzzleStats summarizes the attempts of a player
+// @Streak puzzles solved in a row up to the latest attempt, @BestStreak the longest such run
+type PuzzleStats struct {
+	Name        string  `json:"name"`
+	Attempts    int     `json:"attempts"`
+	Solved      int     `json:"solved"`
+	SuccessRate float64 `json:"success_rate"`
+	Streak      int     `json:"streak"`
+	BestStreak  int     `json:"best_streak"`
+}
+
+func (s PuzzleStats) String() string {
+	return fmt.Sprintf("%s: solved %v of %v (%.0f%%), streak %v, best streak %v",
+		s.Name, s.Solved, s.Attempts, s.SuccessRate*100, s.Streak, s.BestStreak)
+}
+
+// FindPuzzles replays a seeded game and makes a puzzle of every turn where the player missed a big play
+// each position is rebuilt from the bag so the racks are exactly those the players held
+func FindPuzzles(game *Game, opts PuzzleOptions) ([]Puzzle, error) {
+	if opts.MinScore <= 0 {
+		opts.MinScore = defaultPuzzleScore
+	}
+	if opts.MinMissed <= 0 {
+		opts.MinMissed = defaultPuzzleMissed
+	}
+
+	replay, err := newReplay(game)
+	if err != nil {
+		return nil, err
+	}
+
+	var puzzles []Puzzle
+	for _, turn := range game.Turns {
+		player := replay.CurrentPlayer()
+		pos := replay.Position()
+		moves := pos.Moves(DefaultLeaves)
+		board := pos.Board.Copy()
+
+		result, err := replay.replayTurn(turn.input)
+		if err != nil {
+			return puzzles, ErrVerificationFailed{
+				Turn:   turn.number,
+				Reason: fmt.Sprintf("could not replay %q: %v", turn.input, err),
+			}
+		}
+		if len(moves) == 0 {
+			continue
+		}
+
+		played := analyzePlayed(pos, moves, turn.input, result, DefaultLeaves)
+		sort.Stable(ByScore(moves))
+		top := moves[0]
+		if top.Score < opts.MinScore || top.Score-played.Score < opts.MinMissed {
+			continue
+		}
+		puzzles = append(puzzles, Puzzle{
+			GameID:     game.id,
+			Turn:       turn.number,
+			Player:     player.Name,
+			Board:      board,
+			Rack:       pos.Rack(),
+			Rules:      pos.Rules,
+			Answer:     top.Report(),
+			Missed:     played,
+			dictionary: game.Dictionary,
+		})
+	}
+	return puzzles, nil
+}
+
+// Check scores an answer to the puzzle, a `place` input or notation
+// an answer that cannot be played from the rack is refused, a play forming invalid words scores nothing
+func (p Puzzle) Check(input string) (PuzzleAttempt, error) {
+	attempt := PuzzleAttempt{PuzzleID: p.ID, Input: strings.TrimSpace(input), Time: time.Now()}
+	game, err := p.game()
+	if err != nil {
+		return attempt, err
+	}
+	result, err := game.Preview(attempt.Input)
+	if _, phony := err.(ErrInvalidWords); phony {
+		return attempt, nil
+	}
+	if err != nil {
+		return attempt, err
+	}
+
+	var words []string
+	for _, w := range result.Words {
+		words = append(words, w.String())
+	}
+	attempt.Play = strings.Join(words, ",")
+	attempt.Score = result.Score
+	attempt.Solved = result.Score >= p.Answer.Score
+	return attempt, nil
+}
+
+// game sets up the position of the puzzle as a game of a single player to move
+func (p Puzzle) game() (*Game, error) {
+	ts, _, err := p.Rules.TileSetLayout()
+	if err != nil {
+		return nil, err
+	}
+	player := Player{id: 1, nextID: 1, Name: p.Player, tiles: append([]Tile(nil), p.Rack...)}
+	return &Game{
+		board:      p.Board.Copy(),
+		Tiles:      InitializeTileSet(ts, 1),
+		Dictionary: p.dictionary,
+		rules:      p.Rules,
+		players:    []Player{player},
+		Turn:       Turn{number: p.Turn, player: player},
+	}, nil
+}
+
+// puzzleStats totals the attempts of a player, oldest first
+func puzzleStats(name string, attempts []PuzzleAttempt) PuzzleStats {
+	stats := PuzzleStats{Name: name, Attempts: len(attempts)}
+	for _, a := range attempts {
+		if !a.Solved {
+			stats.Streak = 0
+			continue
+		}
+		stats.Solved++
+		stats.Streak++
+		if stats.Streak > stats.BestStreak {
+			stats.BestStreak = stats.Streak
+		}
+	}
+	if stats.Attempts > 0 {
+		stats.SuccessRate = float64(stats.Solved) / float64(stats.Attempts)
+	}
+	return stats
+}
+
+// dailyIndex picks the puzzle of the day out of those available, the same for everyone on a given date
+func dailyIndex(day time.Time, count int) int {
+	days := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60)
+	return int(days % int64(count))
+}
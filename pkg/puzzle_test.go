@@ -0,0 +1,129 @@
+package scrabble
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// missedPuzzleGame plays a game where the first player makes their worst play and the second their best
+func missedPuzzleGame(t *testing.T, gameDB *GameDB) (*Game, Move) {
+	t.Helper()
+	game := newFairGame(t, 42, gameDB)
+	moves := game.Moves(DefaultLeaves)
+	worst := moves[len(moves)-1]
+	if _, err := game.ApplyTurn(worst.Notation(), gameDB); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := game.ApplyTurn(game.Moves(DefaultLeaves)[0].Notation(), gameDB); err != nil {
+		t.Fatal(err)
+	}
+	return game, worst
+}
+
+func TestFindPuzzles(t *testing.T) {
+	game, worst := missedPuzzleGame(t, nil)
+	puzzles, err := FindPuzzles(game, PuzzleOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(puzzles) != 1 {
+		t.Fatalf("found %v puzzles, want the first turn", len(puzzles))
+	}
+	p := puzzles[0]
+	if p.Turn != 1 || p.Player != game.Turns[0].player.Name || p.Missed.Score != worst.Score || p.Answer.Score < defaultPuzzleScore {
+		t.Errorf("puzzle of turn %v for %s, missed %v, answer %v", p.Turn, p.Player, p.Missed, p.Answer)
+	}
+	if !p.Board[7][7].IsEmpty() || len(p.Rack) != HandSize {
+		t.Error("the puzzle should show the empty board and the full rack of the first turn")
+	}
+	if more, _ := FindPuzzles(game, PuzzleOptions{MinScore: p.Answer.Score + 1}); len(more) != 0 {
+		t.Errorf("found %v puzzles scoring more than the top play", len(more))
+	}
+
+	tests := []struct {
+		input  string
+		score  int
+		solved bool
+	}{
+		{p.Answer.Play, p.Answer.Score, true},
+		{worst.Notation(), worst.Score, false},
+	}
+	for _, tt := range tests {
+		attempt, err := p.Check(tt.input)
+		if err != nil || attempt.Score != tt.score || attempt.Solved != tt.solved {
+			t.Errorf("%s: scored %v solved %v (%v), want %v solved %v", tt.input, attempt.Score, attempt.Solved, err, tt.score, tt.solved)
+		}
+	}
+	if _, err := p.Check("h8 ZZZ"); err == nil {
+		t.Error("an answer the rack cannot play should be refused")
+	}
+}
+
+func TestPuzzlesSavedAndAttempted(t *testing.T) {
+	gameDB := newTestDB(t)
+	game, _ := missedPuzzleGame(t, gameDB)
+	puzzles, err := FindPuzzles(game, PuzzleOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []int{1, 0} {
+		added, err := gameDB.InsertPuzzles(puzzles)
+		if err != nil || added != want {
+			t.Fatalf("added %v puzzles (%v), want %v", added, err, want)
+		}
+	}
+
+	loaded, err := gameDB.GetPuzzle(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := puzzles[0]
+	if loaded.GameID != game.GetID() || loaded.Turn != want.Turn || !reflect.DeepEqual(loaded.Rack, want.Rack) ||
+		!reflect.DeepEqual(loaded.Rules, want.Rules) || loaded.Answer != want.Answer || loaded.Missed != want.Missed {
+		t.Errorf("loaded %+v, saved %+v", loaded, want)
+	}
+	if !reflect.DeepEqual(loaded.Board, want.Board) {
+		t.Error("the board of the puzzle changed when saved")
+	}
+	if _, err := gameDB.GetPuzzle(2); err != ErrPuzzleNotFound {
+		t.Errorf("a missing puzzle: got %v", err)
+	}
+
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		daily, err := gameDB.DailyPuzzle(day)
+		if err != nil || daily.ID != 1 {
+			t.Fatalf("puzzle of the day %v (%v)", daily, err)
+		}
+	}
+
+	attempt, err := loaded.Check(loaded.Answer.Play)
+	if err != nil || !attempt.Solved {
+		t.Fatalf("the answer of a loaded puzzle: %+v (%v)", attempt, err)
+	}
+	if err := gameDB.InsertPuzzleAttempt("carol", attempt); err != nil {
+		t.Fatal(err)
+	}
+	if err := gameDB.InsertPuzzleAttempt("carol", attempt); err != ErrPuzzleAttempted {
+		t.Errorf("a second attempt: got %v, want %v", err, ErrPuzzleAttempted)
+	}
+	stats, err := gameDB.PuzzleStats("carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Attempts != 1 || stats.Solved != 1 || stats.Streak != 1 || stats.SuccessRate != 1 {
+		t.Errorf("stats %+v", stats)
+	}
+}
+
+func TestPuzzleStreaks(t *testing.T) {
+	var attempts []PuzzleAttempt
+	for _, solved := range []bool{true, true, true, false, true} {
+		attempts = append(attempts, PuzzleAttempt{Solved: solved})
+	}
+	stats := puzzleStats("carol", attempts)
+	if stats.Attempts != 5 || stats.Solved != 4 || stats.SuccessRate != 0.8 || stats.Streak != 1 || stats.BestStreak != 3 {
+		t.Errorf("stats %+v", stats)
+	}
+}
@@ -0,0 +1,166 @@
+package scrabble
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Challenge rules deciding what happens to a play forming invalid words
+const (
+	// ChallengeVoid rejects the play and the player tries again
+	ChallengeVoid = "void"
+	// ChallengeWithdraw checks every play as it is made, a play with invalid words is withdrawn and the turn lost
+	// as if it had been challenged off, no player decides to challenge so no turn is lost for a wrong challenge
+	ChallengeWithdraw = "withdraw"
+)
+
+// RuleSet represents the rules a game is played with, chosen when the game is created
+// @Layout name of the board layout, @TileSet name of the tile distribution and values
+// @MinSwapBag tiles that must be left in the bag to swap, passing is always allowed
+// @Challenge what happens to a play forming invalid words, ChallengeVoid or ChallengeWithdraw
+// @ScorelessTurns consecutive turns without points that end the game, 0 for no limit
+// @Clabbers a word is valid when any anagram of it is in the dictionary
+// @SharedRack partners of a team play from one rack instead of a rack each
+type RuleSet struct {
+	Name           string `json:"name"`
+	RackSize       int    `json:"rack_size"`
+	BingoBonus     int    `json:"bingo_bonus"`
+	Layout         string `json:"layout"`
+	TileSet        string `json:"tile_set"`
+	MinSwapBag     int    `json:"min_swap_bag"`
+	Challenge      string `json:"challenge"`
+	ScorelessTurns int    `json:"scoreless_turns"`
+	Clabbers       bool   `json:"clabbers,omitempty"`
+	SharedRack     bool   `json:"shared_rack,omitempty"`
+}
+
+// CasualRules are the house rules games were always played with, used when no rules are chosen
+var CasualRules = RuleSet{
+	Name:       "casual",
+	RackSize:   HandSize,
+	BingoBonus: BINGO,
+	Layout:     StandardLayout.Name,
+	TileSet:    EnglishTiles.Name,
+	Challenge:  ChallengeVoid,
+}
+
+// ClassicRules follow tournament play: swaps need a full rack in the bag, phonies are withdrawn and lose the turn
+// and six scoreless turns in a row end the game
+var ClassicRules = RuleSet{
+	Name:           "classic",
+	RackSize:       HandSize,
+	BingoBonus:     BINGO,
+	Layout:         StandardLayout.Name,
+	TileSet:        EnglishTiles.Name,
+	MinSwapBag:     HandSize,
+	Challenge:      ChallengeWithdraw,
+	ScorelessTurns: 6,
+}
+
+// FriendsRules are modelled on Words With Friends, with its board, tiles and a smaller bingo bonus
+var FriendsRules = RuleSet{
+	Name:           "friends",
+	RackSize:       HandSize,
+	BingoBonus:     35,
+	Layout:         FriendsLayout.Name,
+	TileSet:        FriendsTiles.Name,
+	Challenge:      ChallengeVoid,
+	ScorelessTurns: 4,
+}
+
+// ClabbersRules are the casual rules where words may be played in any order of their letters
+var ClabbersRules = RuleSet{
+	Name:       "clabbers",
+	RackSize:   HandSize,
+	BingoBonus: BINGO,
+	Layout:     StandardLayout.Name,
+	TileSet:    EnglishTiles.Name,
+	Challenge:  ChallengeVoid,
+	Clabbers:   true,
+}
+
+// RuleSets maps names to the preset rules, used for parsing player input
+var RuleSets = map[string]RuleSet{
+	CasualRules.Name:   CasualRules,
+	ClassicRules.Name:  ClassicRules,
+	FriendsRules.Name:  FriendsRules,
+	ClabbersRules.Name: ClabbersRules,
+}
+
+// FindRuleSet returns the preset rules with the given name, the casual rules when the name is empty
+func FindRuleSet(name string) (RuleSet, error) {
+	if name == "" {
+		return CasualRules, nil
+	}
+	rules, ok := RuleSets[strings.ToLower(name)]
+	if !ok {
+		return RuleSet{}, ErrUnknownRuleSet{Name: name}
+	}
+	return rules, nil
+}
+
+// RuleSetNames lists the preset rules alphabetically
+func RuleSetNames() []string {
+	var names []string
+	for name := range RuleSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TileSetLayout looks up the tile set and board layout named by the rules
+func (r RuleSet) TileSetLayout() (TileSet, Layout, error) {
+	ts, err := FindTileSet(r.TileSet)
+	if err != nil {
+		return TileSet{}, Layout{}, err
+	}
+	if r.Layout == "" {
+		return ts, StandardLayout, nil
+	}
+	layout, err := FindLayout(r.Layout)
+	return ts, layout, err
+}
+
+// parseRuleSet reads rules stored with a game, games saved before rules existed are casual
+func parseRuleSet(data string) (RuleSet, error) {
+	if data == "" {
+		return CasualRules, nil
+	}
+	var rules RuleSet
+	err := json.Unmarshal([]byte(data), &rules)
+	return rules, err
+}
+
+// orDefault returns the casual rules in place of rules that were never set
+func (r RuleSet) orDefault() RuleSet {
+	if r.RackSize == 0 {
+		return CasualRules
+	}
+	return r
+}
+
+// isWord checks the letters of a row of tiles form a word the rules accept
+func (r RuleSet) isWord(d Dictionary, letters []string) bool {
+	if r.Clabbers {
+		return d.HasAnagram(letters)
+	}
+	return d.HasTiles(letters)
+}
+
+// bingo returns the bonus for placing the given number of tiles
+func (r RuleSet) bingo(placed int) int {
+	if placed == r.RackSize {
+		return r.BingoBonus
+	}
+	return 0
+}
+
+// canSwap checks whether the bag holds enough tiles to swap the given number of tiles
+func (r RuleSet) canSwap(swapped, bag int) bool {
+	if swapped == 0 {
+		return true
+	}
+	return bag >= swapped && bag >= r.MinSwapBag
+}
@@ -0,0 +1,121 @@
+package scrabble
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFindRuleSet(t *testing.T) {
+	for name, want := range map[string]RuleSet{"": CasualRules, "classic": ClassicRules, "Friends": FriendsRules, "CLABBERS": ClabbersRules} {
+		rules, err := FindRuleSet(name)
+		if err != nil || !reflect.DeepEqual(rules, want) {
+			t.Errorf("%q: got %v rules and %v", name, rules.Name, err)
+		}
+	}
+	if _, err := FindRuleSet("chess"); err != (ErrUnknownRuleSet{Name: "chess"}) {
+		t.Errorf("unknown rules: got %v", err)
+	}
+
+	for _, rules := range RuleSets {
+		data, err := json.Marshal(rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if read, err := parseRuleSet(string(data)); err != nil || !reflect.DeepEqual(read, rules) {
+			t.Errorf("%s: read back as %+v (%v)", rules.Name, read, err)
+		}
+	}
+	if rules, err := parseRuleSet(""); err != nil || rules.Name != CasualRules.Name {
+		t.Errorf("a game saved before rules existed reads as %v rules", rules.Name)
+	}
+}
+
+func TestScorelessTurnsEndTheGame(t *testing.T) {
+	tests := []struct {
+		rules RuleSet
+		limit int
+	}{
+		{CasualRules, 0},
+		{ClabbersRules, 0},
+		{ClassicRules, 6},
+		{FriendsRules, 4},
+	}
+	for _, tt := range tests {
+		game := newTestGame(t, "CATSDOGEEIRNTS", "CAT")
+		game.rules = tt.rules
+		if _, err := game.ApplyTurn("h8 CAT", nil); err != nil {
+			t.Fatal(err)
+		}
+		passes := tt.limit
+		if passes == 0 {
+			passes = 20
+		}
+		for i := 1; i <= passes; i++ {
+			if game.IsOver() {
+				t.Fatalf("%s: over after %v passes", tt.rules.Name, i-1)
+			}
+			if _, err := game.ApplyTurn("swap", nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if over := game.IsOver(); over != (tt.limit > 0) {
+			t.Errorf("%s: over %v after %v passes", tt.rules.Name, over, passes)
+		}
+	}
+}
+
+func TestChallengeAndSwapRules(t *testing.T) {
+	// alice holds CATSDOG, six tiles are left in the bag
+	bag := "CATSDOGEEIRNTSAEIOUE"
+	casual := newTestGame(t, bag, "CAT")
+	if _, err := casual.ApplyTurn("h8 DOG", nil); err == nil {
+		t.Error("a phony should be refused under the void rule")
+	} else if _, ok := err.(ErrInvalidWords); !ok || casual.CurrentPlayer().Name != "alice" || len(casual.Turns) != 0 {
+		t.Errorf("a refused phony should leave alice to play again: %v", err)
+	}
+	if _, err := casual.ApplyTurn("swap g", nil); err != nil {
+		t.Errorf("casual rules swap from a short bag: %v", err)
+	}
+
+	classic := newTestGame(t, bag, "CAT")
+	classic.rules = ClassicRules
+	result, err := classic.ApplyTurn("h8 DOG", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Action != "challenged" || !reflect.DeepEqual(result.Invalid, []string{"DOG"}) || result.Score != 0 {
+		t.Errorf("a phony should be withdrawn, got %+v", result)
+	}
+	if classic.CurrentPlayer().Name != "bob" || boardLetter(classic, 7, 7) != "" || classic.ScorelessTurns() != 1 {
+		t.Error("a withdrawn phony should lose the turn and leave the board empty")
+	}
+	if _, err := classic.ApplyTurn("swap e", nil); err != ErrNotEnoughTilesForSwap {
+		t.Errorf("classic rules swap from a short bag: got %v, want %v", err, ErrNotEnoughTilesForSwap)
+	}
+
+	for _, tt := range []struct {
+		rules  RuleSet
+		placed int
+		bonus  int
+	}{
+		{CasualRules, 7, 50}, {CasualRules, 6, 0}, {FriendsRules, 7, 35},
+	} {
+		if bonus := tt.rules.bingo(tt.placed); bonus != tt.bonus {
+			t.Errorf("%s: %v tiles earn %v, want %v", tt.rules.Name, tt.placed, bonus, tt.bonus)
+		}
+	}
+}
+
+func TestRulesSavedWithTheGame(t *testing.T) {
+	gameDB := newTestDB(t)
+	dict := NewDictionary([]string{"CAT"})
+	game := NewGameWithOptions([]PlayerRequest{{Name: "alice"}, {Name: "bob"}}, GameOptions{Seed: 3, Dictionary: &dict, Rules: &ClassicRules}, gameDB)
+	loaded, err := gameDB.GetGameByID(int(game.GetID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Rules(), ClassicRules) {
+		t.Errorf("loaded %+v, want the classic rules", loaded.Rules())
+	}
+}
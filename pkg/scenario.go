@@ -0,0 +1,233 @@
+package scrabble
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Scenario represents a scripted game read from a file
+// @Players take turns in the order listed
+// @Seed seeds the bag, 0 picks a random seed unless a bag is given
+// @Bag pre-orders the bag, the first tiles listed are drawn first
+// @Rules rules of the game, the casual rules when nil
+// @Layout board of the game, the board of the rules when nil
+// @TileSet tiles of the game, the tiles of the rules when nil
+// @Dictionary word list of the game, the dictionary of the tile set when nil
+// @SharedRack partners of a team play from one rack
+// @Moves inputs applied in order, as typed at the move prompt
+type Scenario struct {
+	Players    []PlayerRequest
+	Seed       int64
+	Bag        []Tile
+	Rules      *RuleSet
+	Layout     *Layout
+	TileSet    *TileSet
+	Dictionary *Dictionary
+	SharedRack bool
+	Moves      []ScenarioMove
+}
+
+// ScenarioMove is a single input of a scenario and the line it was read from
+type ScenarioMove struct {
+	Line  int
+	Input string
+}
+
+// LoadScenario reads a scenario file
+func LoadScenario(path string) (Scenario, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Scenario{}, err
+	}
+	defer file.Close()
+	return ParseScenario(file)
+}
+
+// ParseScenario reads a scenario, one directive or move per line
+// `player NAME [LEVEL]`, `seed N`, `bag LETTERS` (`_` for blanks) and `layout NAME|FILE` set up the game
+// `rules NAME` picks preset rules and `tiles NAME` the tile set, both before any bag
+// `dictionary FILE` loads a word list spelled with the tile set
+// `team NAME PLAYER...` puts listed players in a team, `racks shared` has partners play from one rack
+// bag letters of several characters are read longest first, brackets keep letters apart ([C][H])
+// moves are `place` and `swap` inputs or plays in notation, `pass` passes the turn and `bot` lets a computer player choose,
+// any other line is a format error
+// lines starting with # are ignored
+func ParseScenario(r io.Reader) (Scenario, error) {
+	var scenario Scenario
+	scanner := bufio.NewScanner(r)
+	var line, dictLine int
+	var dictPath string
+	ts := EnglishTiles
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		switch fields[0] {
+		case "player":
+			if len(fields) < 2 || len(fields) > 3 {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "expected `player NAME [LEVEL]`"}
+			}
+			player := PlayerRequest{Name: fields[1]}
+			if len(fields) == 3 {
+				level, ok := BotLevels[fields[2]]
+				if !ok {
+					return scenario, ErrScenarioFormat{Line: line, Reason: "unknown bot level " + fields[2]}
+				}
+				player.Bot = level
+			}
+			scenario.Players = append(scenario.Players, player)
+		case "team":
+			if len(fields) < 3 {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "expected `team NAME PLAYER...`"}
+			}
+			for _, name := range fields[2:] {
+				found := false
+				for i, p := range scenario.Players {
+					if p.Name == name {
+						scenario.Players[i].Team = fields[1]
+						found = true
+					}
+				}
+				if !found {
+					return scenario, ErrScenarioFormat{Line: line, Reason: "unknown player " + name}
+				}
+			}
+		case "racks":
+			if len(fields) != 2 || (fields[1] != "shared" && fields[1] != "separate") {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "expected `racks shared|separate`"}
+			}
+			scenario.SharedRack = fields[1] == "shared"
+		case "seed":
+			if len(fields) != 2 {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "expected `seed N`"}
+			}
+			seed, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "seed must be a number"}
+			}
+			scenario.Seed = seed
+		case "bag":
+			if len(fields) != 2 {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "expected `bag LETTERS`"}
+			}
+			var letters []string
+			for _, l := range ts.splitInput(strings.ToUpper(fields[1])) {
+				letters = append(letters, unbracket(l))
+			}
+			scenario.Bag = ts.parseTiles(letters)
+		case "rules":
+			if len(fields) != 2 {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "expected `rules NAME`"}
+			}
+			if scenario.Bag != nil {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "rules must be set before the bag"}
+			}
+			rules, err := FindRuleSet(fields[1])
+			if err != nil {
+				return scenario, ErrScenarioFormat{Line: line, Reason: err.Error()}
+			}
+			if scenario.TileSet == nil {
+				ts, _, err = rules.TileSetLayout()
+				if err != nil {
+					return scenario, ErrScenarioFormat{Line: line, Reason: err.Error()}
+				}
+			}
+			scenario.Rules = &rules
+		case "tiles":
+			if len(fields) != 2 {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "expected `tiles NAME`"}
+			}
+			if scenario.Bag != nil {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "tiles must be set before the bag"}
+			}
+			set, err := FindTileSet(fields[1])
+			if err != nil {
+				return scenario, ErrScenarioFormat{Line: line, Reason: err.Error()}
+			}
+			ts = set
+			scenario.TileSet = &set
+		case "dictionary":
+			if len(fields) != 2 {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "expected `dictionary FILE`"}
+			}
+			dictPath, dictLine = fields[1], line
+		case "layout":
+			if len(fields) != 2 {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "expected `layout NAME|FILE`"}
+			}
+			layout, err := FindLayout(fields[1])
+			if err != nil {
+				return scenario, ErrScenarioFormat{Line: line, Reason: err.Error()}
+			}
+			scenario.Layout = &layout
+		case "pass":
+			scenario.Moves = append(scenario.Moves, ScenarioMove{Line: line, Input: "swap"})
+		case "place", "swap", "bot":
+			scenario.Moves = append(scenario.Moves, ScenarioMove{Line: line, Input: text})
+		default:
+			// a mistyped directive is reported here rather than failing later as a move
+			if !isNotation(text) {
+				return scenario, ErrScenarioFormat{Line: line, Reason: "unknown directive " + fields[0]}
+			}
+			scenario.Moves = append(scenario.Moves, ScenarioMove{Line: line, Input: text})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return scenario, err
+	}
+	if len(scenario.Players) == 0 {
+		return scenario, ErrScenarioFormat{Line: line, Reason: "no players listed"}
+	}
+	if err := ValidateTeams(scenario.Players); err != nil {
+		return scenario, ErrScenarioFormat{Line: line, Reason: err.Error()}
+	}
+	if dictPath != "" {
+		dict, err := LoadTileSetDictionary(dictPath, ts)
+		if err != nil {
+			return scenario, ErrScenarioFormat{Line: dictLine, Reason: err.Error()}
+		}
+		scenario.Dictionary = &dict
+	}
+	return scenario, nil
+}
+
+// Play applies every move of the scenario to a new game without a database
+// stops at the first move that fails, returning the game as it was before that move
+// a game where a player went out is ended and scored
+func (s Scenario) Play() (*Game, error) {
+	game := NewGameWithOptions(s.Players, GameOptions{
+		Seed:       s.Seed,
+		Bag:        s.Bag,
+		Rules:      s.Rules,
+		Layout:     s.Layout,
+		TileSet:    s.TileSet,
+		Dictionary: s.Dictionary,
+		SharedRack: s.SharedRack,
+		FixedOrder: true,
+	}, nil)
+	for _, m := range s.Moves {
+		if game.IsOver() {
+			return game, ErrScenarioMove{Line: m.Line, Turn: game.Turn.number, Input: m.Input, Err: ErrGameOver}
+		}
+
+		input := m.Input
+		current := game.CurrentPlayer()
+		if current.Bot != "" && input == "bot" {
+			input = NewBot(current.Bot).Turn(game)
+		}
+		_, err := game.ApplyTurn(input, nil)
+		if err != nil {
+			return game, ErrScenarioMove{Line: m.Line, Turn: game.Turn.number, Input: input, Err: err}
+		}
+	}
+	if game.IsOver() {
+		game.End()
+	}
+	return game, nil
+}
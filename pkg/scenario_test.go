@@ -0,0 +1,48 @@
+package scrabble
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseScenarioRejectsUnknownDirectives(t *testing.T) {
+	tests := []struct {
+		text string
+		line int
+	}{
+		{"player alice\nplayer bob\nsede 42\nh8 CAT\n", 3},
+		{"player alice\nplayer bob\nh8 CAT\npas\n", 4},
+		{"player alice\nplayer bob\nh8\n", 3},
+	}
+	for _, tt := range tests {
+		_, err := ParseScenario(strings.NewReader(tt.text))
+		if format, ok := err.(ErrScenarioFormat); !ok || format.Line != tt.line {
+			t.Errorf("%q: got %v, want a format error on line %v", tt.text, err, tt.line)
+		}
+	}
+
+	scenario, err := ParseScenario(strings.NewReader("player alice\nplayer bob\nh8 CAT\nplace t(h,8)\nswap a\npass\nbot\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scenario.Moves) != 5 {
+		t.Errorf("read %v moves, want 5", len(scenario.Moves))
+	}
+}
+
+func TestScenarioPlayStopsAtFailedMove(t *testing.T) {
+	scenario, err := ParseScenario(strings.NewReader("player alice\nplayer bob\nbag CATSDOGEEIRNTS\nh8 CAT\n\nh1 CATS\npass\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dict := NewDictionary([]string{"CAT", "CATS"})
+	scenario.Dictionary = &dict
+	game, err := scenario.Play()
+	move, ok := err.(ErrScenarioMove)
+	if !ok || move.Line != 6 || move.Turn != 2 || move.Input != "h1 CATS" {
+		t.Fatalf("got %v, want the move on line 6 to fail", err)
+	}
+	if len(game.Turns) != 1 || game.CurrentPlayer().Name != "bob" || boardLetter(game, 7, 7) != "C" {
+		t.Errorf("the game should be left after the first move, %v turns played", len(game.Turns))
+	}
+}
@@ -0,0 +1,283 @@
+package scrabble
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Limits of self play games
+const (
+	defaultSelfPlayGames = 100
+	maxScorelessTurns    = 6
+	confidenceZ          = 1.96
+)
+
+// SelfPlayOptions controls a series of games between two computer players
+// @Players the two seats, each request must name a bot level or an engine command
+// @Games number of games played, 0 for the default
+// @Seed derives the bag of every game, 0 picks a random seed
+// @Workers number of games played at once, 0 for one per cpu
+// @EngineLog records the conversation with external engines when set
+type SelfPlayOptions struct {
+	Players   []PlayerRequest
+	Games     int
+	Seed      int64
+	Workers   int
+	EngineLog *log.Logger
+}
+
+// SelfPlayGame represents the outcome of a single self play game
+// @Scores, @Bingos indexed like the requested players
+// @Winner index of the winning player, -1 for a tie
+type SelfPlayGame struct {
+	Game   int   `json:"game"`
+	Seed   int64 `json:"seed"`
+	Turns  int   `json:"turns"`
+	Scores []int `json:"scores"`
+	Bingos []int `json:"bingos"`
+	Winner int   `json:"winner"`
+}
+
+// SelfPlayStats summarizes the games of one player
+// @Wins ties count as half a win
+// @WinRateCI, @ScoreCI half width of the 95% confidence interval
+type SelfPlayStats struct {
+	Name          string   `json:"name"`
+	Bot           BotLevel `json:"bot,omitempty"`
+	Engine        string   `json:"engine,omitempty"`
+	Wins          float64  `json:"wins"`
+	WinRate       float64  `json:"win_rate"`
+	WinRateCI     float64  `json:"win_rate_ci"`
+	AverageScore  float64  `json:"average_score"`
+	ScoreCI       float64  `json:"score_ci"`
+	AverageSpread float64  `json:"average_spread"`
+	BingosPerGame float64  `json:"bingos_per_game"`
+}
+
+// SelfPlayResult represents every game played and the statistics of each player
+type SelfPlayResult struct {
+	Seed         int64           `json:"seed"`
+	AverageTurns float64         `json:"average_turns"`
+	Players      []SelfPlayStats `json:"players"`
+	Games        []SelfPlayGame  `json:"games"`
+}
+
+func (r SelfPlayResult) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v games (seed %v), average length %.1f turns\n--------------------\n",
+		len(r.Games), r.Seed, r.AverageTurns)
+	for _, p := range r.Players {
+		player := string(p.Bot)
+		if p.Engine != "" {
+			player = p.Engine
+		}
+		fmt.Fprintf(&b, "%s (%s): win rate %.1f%% ± %.1f%%, average score %.1f ± %.1f, average spread %+.1f, %.2f bingos per game\n",
+			p.Name, player, p.WinRate*100, p.WinRateCI*100, p.AverageScore, p.ScoreCI, p.AverageSpread, p.BingosPerGame)
+	}
+	return b.String()
+}
+
+// WriteCSV writes one row per game
+func (r SelfPlayResult) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	header := []string{"game", "seed", "turns", "winner"}
+	for _, p := range r.Players {
+		header = append(header, p.Name+" score", p.Name+" bingos")
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, g := range r.Games {
+		winner := "tie"
+		if g.Winner >= 0 {
+			winner = r.Players[g.Winner].Name
+		}
+		row := []string{strconv.Itoa(g.Game), strconv.FormatInt(g.Seed, 10), strconv.Itoa(g.Turns), winner}
+		for i := range r.Players {
+			row = append(row, strconv.Itoa(g.Scores[i]), strconv.Itoa(g.Bingos[i]))
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// SelfPlay plays complete games between two computer players without a database
+// game i is dealt from a bag seeded by the series seed, so a series can be repeated exactly
+func SelfPlay(opts SelfPlayOptions) (SelfPlayResult, error) {
+	if len(opts.Players) != 2 || opts.Players[0].Name == opts.Players[1].Name {
+		return SelfPlayResult{}, ErrSelfPlayPlayers
+	}
+	for _, p := range opts.Players {
+		if p.Bot == "" && p.Engine == "" {
+			return SelfPlayResult{}, ErrSelfPlayBot
+		}
+	}
+	if opts.Games <= 0 {
+		opts.Games = defaultSelfPlayGames
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	for opts.Seed == 0 {
+		opts.Seed = rand.Int63()
+	}
+
+	// every game shares one dictionary so the move generator index is only built once
+	dict, err := DefaultDictionary()
+	if err != nil {
+		return SelfPlayResult{}, err
+	}
+
+	games := make([]SelfPlayGame, opts.Games)
+	errs := make([]error, opts.Games)
+	var next int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				i := next
+				next++
+				mu.Unlock()
+				if i >= opts.Games {
+					return
+				}
+				games[i], errs[i] = playSelfPlayGame(i, mixSeed(opts.Seed, int64(i)), opts, &dict)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return SelfPlayResult{}, err
+		}
+	}
+	return summarizeSelfPlay(opts, games), nil
+}
+
+// playSelfPlayGame plays a single game until a player goes out
+// the game also ends after too many scoreless turns in a row, when both bots can only pass
+func playSelfPlayGame(number int, seed int64, opts SelfPlayOptions, dict *Dictionary) (SelfPlayGame, error) {
+	players := opts.Players
+	rules := CasualRules
+	rules.ScorelessTurns = maxScorelessTurns
+	game := NewGameWithOptions(players, GameOptions{Seed: seed, Dictionary: dict, Rules: &rules}, nil)
+	result := SelfPlayGame{
+		Game:   number,
+		Seed:   seed,
+		Scores: make([]int, len(players)),
+		Bingos: make([]int, len(players)),
+		Winner: -1,
+	}
+
+	seat := make(map[string]int)
+	for i, p := range players {
+		seat[p.Name] = i
+	}
+
+	seats, err := game.ComputerPlayers(opts.EngineLog)
+	if err != nil {
+		return result, err
+	}
+	defer CloseComputerPlayers(seats)
+
+	for !game.IsOver() {
+		current := game.CurrentPlayer()
+		input, turn, err := game.PlayComputerTurn(seats[current.Name], nil)
+		if err != nil {
+			return result, ErrSelfPlayFailed{Game: number, Input: input, Err: err}
+		}
+
+		result.Turns++
+		if isBingo(turn, game.Rules().RackSize) {
+			result.Bingos[seat[current.Name]]++
+		}
+	}
+	game.End()
+
+	for _, p := range game.GetPlayers() {
+		result.Scores[seat[p.Name]] = p.Score()
+	}
+	switch {
+	case result.Scores[0] > result.Scores[1]:
+		result.Winner = 0
+	case result.Scores[1] > result.Scores[0]:
+		result.Winner = 1
+	}
+	return result, nil
+}
+
+// summarizeSelfPlay computes the statistics of each player over every game
+func summarizeSelfPlay(opts SelfPlayOptions, games []SelfPlayGame) SelfPlayResult {
+	result := SelfPlayResult{
+		Seed:  opts.Seed,
+		Games: games,
+	}
+	n := float64(len(games))
+	for _, g := range games {
+		result.AverageTurns += float64(g.Turns) / n
+	}
+
+	for i, p := range opts.Players {
+		stats := SelfPlayStats{
+			Name:   p.Name,
+			Bot:    p.Bot,
+			Engine: p.Engine,
+		}
+		var bingos, spread float64
+		scores := make([]float64, len(games))
+		for j, g := range games {
+			switch g.Winner {
+			case i:
+				stats.Wins++
+			case -1:
+				stats.Wins += 0.5
+			}
+			scores[j] = float64(g.Scores[i])
+			spread += float64(g.Scores[i] - g.Scores[1-i])
+			bingos += float64(g.Bingos[i])
+		}
+
+		stats.WinRate = stats.Wins / n
+		stats.WinRateCI = confidenceZ * math.Sqrt(stats.WinRate*(1-stats.WinRate)/n)
+		stats.AverageScore, stats.ScoreCI = meanInterval(scores)
+		stats.AverageSpread = spread / n
+		stats.BingosPerGame = bingos / n
+		result.Players = append(result.Players, stats)
+	}
+	return result
+}
+
+// meanInterval returns the mean of the samples and the half width of its 95% confidence interval
+func meanInterval(samples []float64) (float64, float64) {
+	n := float64(len(samples))
+	var mean float64
+	for _, s := range samples {
+		mean += s / n
+	}
+	if len(samples) < 2 {
+		return mean, 0
+	}
+	var variance float64
+	for _, s := range samples {
+		variance += (s - mean) * (s - mean)
+	}
+	variance /= n - 1
+	return mean, confidenceZ * math.Sqrt(variance/n)
+}
@@ -0,0 +1,85 @@
+package scrabble
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelfPlayPlayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []PlayerRequest
+		want    error
+	}{
+		{"one player", []PlayerRequest{{Name: "a", Bot: BotEasy}}, ErrSelfPlayPlayers},
+		{"same name", []PlayerRequest{{Name: "a", Bot: BotEasy}, {Name: "a", Bot: BotHard}}, ErrSelfPlayPlayers},
+		{"human", []PlayerRequest{{Name: "a", Bot: BotEasy}, {Name: "b"}}, ErrSelfPlayBot},
+	}
+	for _, tt := range tests {
+		if _, err := SelfPlay(SelfPlayOptions{Players: tt.players, Games: 1}); err != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestSelfPlayGameRepeats(t *testing.T) {
+	dict, err := LoadDictionary(filepath.Join("..", dictPath))
+	if err != nil {
+		t.Skipf("dictionary not available: %v", err)
+	}
+	opts := SelfPlayOptions{Players: []PlayerRequest{{Name: "easy", Bot: BotEasy}, {Name: "medium", Bot: BotMedium}}}
+	game, err := playSelfPlayGame(1, 11, opts, &dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Turns == 0 || game.Seed != 11 {
+		t.Errorf("game of %v turns from seed %v", game.Turns, game.Seed)
+	}
+	winner := -1
+	switch {
+	case game.Scores[0] > game.Scores[1]:
+		winner = 0
+	case game.Scores[1] > game.Scores[0]:
+		winner = 1
+	}
+	if game.Winner != winner {
+		t.Errorf("winner %v with scores %v", game.Winner, game.Scores)
+	}
+	if again, err := playSelfPlayGame(1, 11, opts, &dict); err != nil || !reflect.DeepEqual(again, game) {
+		t.Errorf("the same seed played %+v then %+v (%v)", game, again, err)
+	}
+}
+
+func TestSummarizeSelfPlay(t *testing.T) {
+	opts := SelfPlayOptions{Seed: 9, Players: []PlayerRequest{{Name: "a", Bot: BotEasy}, {Name: "b", Bot: BotHard}}}
+	games := []SelfPlayGame{
+		{Game: 1, Seed: 1, Turns: 20, Scores: []int{300, 400}, Bingos: []int{0, 2}, Winner: 1},
+		{Game: 2, Seed: 2, Turns: 24, Scores: []int{350, 350}, Bingos: []int{1, 1}, Winner: -1},
+	}
+	result := summarizeSelfPlay(opts, games)
+	if result.AverageTurns != 22 {
+		t.Errorf("average turns %v, want 22", result.AverageTurns)
+	}
+	a, b := result.Players[0], result.Players[1]
+	if a.Wins != 0.5 || b.Wins != 1.5 || b.WinRate != 0.75 {
+		t.Errorf("wins %v and %v, win rate of b %v", a.Wins, b.Wins, b.WinRate)
+	}
+	if a.AverageScore != 325 || a.AverageSpread != -50 || b.AverageSpread != 50 || b.BingosPerGame != 1.5 {
+		t.Errorf("a: %+v, b: %+v", a, b)
+	}
+
+	var out bytes.Buffer
+	if err := result.WriteCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+	want := "game,seed,turns,winner,a score,a bingos,b score,b bingos\n1,1,20,b,300,0,400,2\n2,2,24,tie,350,1,350,1\n"
+	if out.String() != want {
+		t.Errorf("csv:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if !strings.Contains(result.String(), "2 games (seed 9)") {
+		t.Errorf("summary:\n%s", result)
+	}
+}
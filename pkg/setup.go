@@ -0,0 +1,160 @@
+package scrabble
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PositionSetup describes a position set up by hand rather than reached by play,
+// such as one from a game played away from the computer
+// @Rows the board one row per line as written by Board.Rows, `.` for empty squares and lower case for blanks, empty for an empty board
+// @Plays plays in notation laid on the board in order after the rows (h8 QUIXOTIC), the words are not checked
+// @Rack tiles of the player to move, `_` or `?` for a blank
+// @Unseen tiles in the bag and on the opponent's rack, every tile of the set not on the board or the rack when empty
+// @Scores of the player to move, then of the opponent
+// @Rules rules of the position, the casual rules when nil, their layout and tile set are used unless others are given
+// @Layout, @TileSet board and tiles of the position, those of the rules when nil
+// @Dictionary word list of the position, the dictionary of the tile set when nil
+type PositionSetup struct {
+	Rows       []string
+	Plays      []string
+	Rack       string
+	Unseen     string
+	Scores     [2]int
+	Rules      *RuleSet
+	Layout     *Layout
+	TileSet    *TileSet
+	Dictionary *Dictionary
+}
+
+// Position builds the position of the setup for two players, the player to move first
+// the bag holds the unseen tiles beyond a full rack for the opponent, so few enough unseen tiles make an endgame
+func (s PositionSetup) Position() (Position, error) {
+	rules := CasualRules
+	if s.Rules != nil {
+		rules = *s.Rules
+	}
+	ts, layout, err := rules.TileSetLayout()
+	if err != nil {
+		return Position{}, err
+	}
+	if s.TileSet != nil {
+		ts = *s.TileSet
+	}
+	if s.Layout != nil {
+		layout = *s.Layout
+	}
+	rules.Layout, rules.TileSet = layout.Name, ts.Name
+
+	var dict Dictionary
+	if s.Dictionary != nil {
+		dict = *s.Dictionary
+	} else {
+		dict, err = LoadTileSetDictionary(ts.Dictionary, ts)
+		if err != nil {
+			return Position{}, err
+		}
+	}
+
+	board, err := SetupBoard(layout, ts, s.Rows, s.Plays)
+	if err != nil {
+		return Position{}, err
+	}
+	rack, err := parseSetupTiles(ts, s.Rack)
+	if err != nil {
+		return Position{}, err
+	}
+	if len(rack) == 0 || len(rack) > rules.RackSize {
+		return Position{}, ErrPositionSetup{Reason: fmt.Sprintf("the rack needs 1 to %v tiles", rules.RackSize)}
+	}
+
+	unseen := CountUnseen(board, rack, ts).Tiles()
+	if s.Unseen != "" {
+		unseen, err = parseSetupTiles(ts, s.Unseen)
+		if err != nil {
+			return Position{}, err
+		}
+	}
+	bag := len(unseen) - rules.RackSize
+	if bag < 0 {
+		bag = 0
+	}
+
+	return Position{
+		Board:      board,
+		Racks:      [][]Tile{rack, nil},
+		Scores:     s.Scores[:],
+		ToMove:     0,
+		Unseen:     unseen,
+		BagSize:    bag,
+		Dictionary: dict,
+		Rules:      rules,
+	}, nil
+}
+
+// SetupBoard lays tiles on an empty board of the layout, from rows of letters then plays in notation
+// squares covered by tiles count as used, their premiums are not scored again
+func SetupBoard(layout Layout, ts TileSet, rows []string, plays []string) (Board, error) {
+	board := layout.NewBoard()
+	if len(rows) > 0 && len(rows) != board.Size() {
+		return nil, ErrPositionSetup{Reason: fmt.Sprintf("expected %v rows, found %v", board.Size(), len(rows))}
+	}
+	for x, row := range rows {
+		squares := ts.splitInput(row)
+		if len(squares) != board.Size() {
+			return nil, ErrPositionSetup{Reason: fmt.Sprintf("row %v has %v squares, expected %v", x+1, len(squares), board.Size())}
+		}
+		for y, text := range squares {
+			text = unbracket(text)
+			if text == "." {
+				continue
+			}
+			tile, err := setupTile(ts, text)
+			if err != nil {
+				return nil, err
+			}
+			board[x][y].Value = tile
+			board[x][y].Used = true
+		}
+	}
+
+	for _, play := range plays {
+		placements, err := parseNotation(board, ts, play)
+		if err != nil {
+			return nil, err
+		}
+		board = board.WithMove(Move{Placements: placements})
+	}
+	return board, nil
+}
+
+// parseSetupTiles reads a row of tiles such as a rack, `_` or `?` for a blank
+func parseSetupTiles(ts TileSet, text string) ([]Tile, error) {
+	var tiles []Tile
+	for _, letter := range ts.splitInput(normalizeQuery(text)) {
+		letter = unbracket(letter)
+		if _, ok := ts.Counts[letter]; !ok {
+			return nil, ErrPositionSetup{Reason: fmt.Sprintf("%s is not a tile of the %s set", letter, ts.Name)}
+		}
+		tiles = append(tiles, ts.Tile(letter))
+	}
+	return tiles, nil
+}
+
+// setupTile reads the tile on a square of a row, a lower case letter is a blank
+func setupTile(ts TileSet, text string) (Tile, error) {
+	r, _ := utf8.DecodeRuneInString(text)
+	letter := strings.ToUpper(text)
+	if letter == "_" {
+		return Tile{}, ErrPositionSetup{Reason: "a blank on the board must be written as the lower case letter it stands for"}
+	}
+	if _, ok := ts.Counts[letter]; !ok {
+		return Tile{}, ErrPositionSetup{Reason: fmt.Sprintf("%s is not a tile of the %s set", text, ts.Name)}
+	}
+	if unicode.IsLower(r) {
+		return Tile{Letter: letter, Value: 0, IsBlank: true}, nil
+	}
+	return ts.Tile(letter), nil
+}
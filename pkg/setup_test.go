@@ -0,0 +1,86 @@
+package scrabble
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSetupBoard(t *testing.T) {
+	rows := make([]string, 15)
+	for i := range rows {
+		rows[i] = strings.Repeat(".", 15)
+	}
+	rows[7] = ".......CaT....."
+	board, err := SetupBoard(StandardLayout, EnglishTiles, rows, []string{"8h CAX"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board[7][7].Value.Letter != "C" || !board[7][8].Value.IsBlank || board[7][8].Value.Value != 0 || !board[7][7].Used {
+		t.Errorf("row h read as %v", board[7])
+	}
+	if board[8][7].Value.Letter != "A" || board[9][7].Value.Letter != "X" {
+		t.Error("the play down from h8 should cover i8 and j8")
+	}
+	// the rows written by a board are read back as the same board
+	if again, err := SetupBoard(StandardLayout, EnglishTiles, board.Rows(), nil); err != nil || !reflect.DeepEqual(again, board) {
+		t.Errorf("rows of the board read back differently: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		rows  []string
+		plays []string
+	}{
+		{"too few rows", rows[:14], nil},
+		{"short row", append(append([]string(nil), rows[:14]...), "..."), nil},
+		{"unknown tile", append(append([]string(nil), rows[:14]...), ".......1......."), nil},
+		{"play off the board", nil, []string{"h14 CAT"}},
+	}
+	for _, tt := range tests {
+		if _, err := SetupBoard(StandardLayout, EnglishTiles, tt.rows, tt.plays); err == nil {
+			t.Errorf("%s: should not be set up", tt.name)
+		}
+	}
+}
+
+func TestSetupPosition(t *testing.T) {
+	dict := NewDictionary([]string{"CAT", "CATS", "SCAT"})
+	setup := PositionSetup{Plays: []string{"h8 CAT"}, Rack: "S", Scores: [2]int{10, 30}, Dictionary: &dict}
+	pos, err := setup.Position()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pos.Unseen) != 96 || pos.BagSize != 89 || !reflect.DeepEqual(pos.Scores, []int{10, 30}) || pos.Rules.Name != CasualRules.Name {
+		t.Errorf("%v unseen, bag of %v, scores %v", len(pos.Unseen), pos.BagSize, pos.Scores)
+	}
+	var words []string
+	for _, m := range pos.Moves(DefaultLeaves) {
+		words = append(words, m.Word)
+	}
+	if want := []string{"CATS", "SCAT"}; !reflect.DeepEqual(sortedCopy(words), want) {
+		t.Errorf("moves %v, want %v", words, want)
+	}
+
+	// few enough unseen tiles leave the bag empty for an endgame
+	setup.Unseen = "QI"
+	if pos, err = setup.Position(); err != nil || pos.BagSize != 0 || len(pos.Unseen) != 2 {
+		t.Errorf("endgame with %v unseen and a bag of %v (%v)", len(pos.Unseen), pos.BagSize, err)
+	}
+
+	for name, rack := range map[string]string{"empty rack": "", "rack too large": "ABCDEFGH", "unknown tile": "A1"} {
+		setup.Rack = rack
+		if _, err := setup.Position(); err == nil {
+			t.Errorf("%s: should not be set up", name)
+		} else if _, ok := err.(ErrPositionSetup); !ok {
+			t.Errorf("%s: got %v, want a setup error", name, err)
+		}
+	}
+}
+
+func sortedCopy(words []string) []string {
+	sorted := append([]string(nil), words...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,256 @@
+package scrabble
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default budget of a simulation
+const (
+	defaultSimPlies      = 2
+	defaultSimCandidates = 10
+	defaultSimIterations = 1000
+)
+
+// SimOptions controls the budget of a simulation
+// @Plies number of plays simulated, including the candidate itself
+// @Iterations maximum number of sampled racks, 0 for the default
+// @Duration stops the simulation early once elapsed, 0 for no limit
+// @Workers number of goroutines used, 0 for one per cpu
+type SimOptions struct {
+	Plies      int
+	Iterations int
+	Duration   time.Duration
+	Workers    int
+	Seed       int64
+	Leaves     LeaveTable
+}
+
+// SimResult represents the outcome of simulating a single candidate play
+// @Spread average points gained over the opponent, including the final leave
+// @WinPct share of iterations where the player to move was ahead at the end
+type SimResult struct {
+	Move       Move
+	Iterations int
+	Spread     float64
+	WinPct     float64
+}
+
+func (r SimResult) String() string {
+	return fmt.Sprintf("%s | spread %+.1f win %.1f%% (%v iterations)",
+		r.Move, r.Spread, r.WinPct*100, r.Iterations)
+}
+
+// Simulate plays out each candidate a few plies ahead against sampled opponent racks
+// every iteration deals the same racks to all candidates so they are compared fairly
+// replies are chosen by static equity, results are sorted by win percentage then spread
+func Simulate(pos Position, candidates []Move, opts SimOptions) []SimResult {
+	if opts.Plies <= 0 {
+		opts.Plies = defaultSimPlies
+	}
+	if opts.Iterations <= 0 {
+		opts.Iterations = defaultSimIterations
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.Leaves.Values == nil {
+		opts.Leaves = DefaultLeaves
+	}
+	opts.Leaves = opts.Leaves.forRules(pos.Rules)
+	if opts.Seed == 0 {
+		opts.Seed = rand.Int63()
+	}
+
+	results := make([]SimResult, len(candidates))
+	totals := make([]struct{ spread, wins float64 }, len(candidates))
+	for i, c := range candidates {
+		results[i].Move = c
+	}
+
+	var deadline time.Time
+	if opts.Duration > 0 {
+		deadline = time.Now().Add(opts.Duration)
+	}
+
+	var mu sync.Mutex
+	var next int
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(mixSeed(opts.Seed, int64(worker))))
+			for {
+				mu.Lock()
+				if next >= opts.Iterations || (!deadline.IsZero() && time.Now().After(deadline)) {
+					mu.Unlock()
+					return
+				}
+				next++
+				mu.Unlock()
+
+				racks, bag := pos.sampleRacks(rng)
+				for i, c := range candidates {
+					spread, win := pos.playout(c, racks, bag, opts)
+					mu.Lock()
+					totals[i].spread += spread
+					totals[i].wins += win
+					results[i].Iterations++
+					mu.Unlock()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for i := range results {
+		if results[i].Iterations == 0 {
+			continue
+		}
+		n := float64(results[i].Iterations)
+		results[i].Spread = totals[i].spread / n
+		results[i].WinPct = totals[i].wins / n
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].WinPct == results[j].WinPct {
+			return results[i].Spread > results[j].Spread
+		}
+		return results[i].WinPct > results[j].WinPct
+	})
+	return results
+}
+
+// SimulateTop simulates the best candidates of the player to move by static equity
+func (pos Position) SimulateTop(candidates int, opts SimOptions) []SimResult {
+	if candidates <= 0 {
+		candidates = defaultSimCandidates
+	}
+	if opts.Leaves.Values == nil {
+		opts.Leaves = DefaultLeaves
+	}
+	moves := pos.Moves(opts.Leaves)
+	if len(moves) > candidates {
+		moves = moves[:candidates]
+	}
+	return Simulate(pos, moves, opts)
+}
+
+// sampleRacks deals random racks to every other player from the unseen tiles, partners sharing
+// a rack are dealt a single one, the tiles left over make up the bag
+func (pos Position) sampleRacks(rng *rand.Rand) ([][]Tile, []Tile) {
+	unseen := make([]Tile, len(pos.Unseen))
+	copy(unseen, pos.Unseen)
+	rng.Shuffle(len(unseen), func(i, j int) {
+		unseen[i], unseen[j] = unseen[j], unseen[i]
+	})
+
+	racks := make([][]Tile, len(pos.Racks))
+	for i := range pos.Racks {
+		if pos.sharesRack(i, pos.ToMove) {
+			racks[i] = pos.Rack()
+			continue
+		}
+		if j := pos.side(i); j < i && pos.sharesRack(i, j) {
+			racks[i] = racks[j]
+			continue
+		}
+		size := len(pos.Racks[i])
+		if size == 0 || size > len(unseen) {
+			size = min(pos.Rules.orDefault().RackSize, len(unseen))
+		}
+		racks[i] = unseen[:size]
+		unseen = unseen[size:]
+	}
+	return racks, unseen
+}
+
+// playout plays the candidate then the best static reply for the following plies
+// returns the spread gained by the side to move and 1, 0.5 or 0 for a win, tie or loss
+// partners of a team game are on the same side, their scores are added up
+func (pos Position) playout(candidate Move, dealt [][]Tile, dealtBag []Tile, opts SimOptions) (float64, float64) {
+	me := pos.ToMove
+	board := pos.Board
+	scores := append([]int(nil), pos.Scores...)
+	racks := make([][]Tile, len(dealt))
+	for i, r := range dealt {
+		racks[i] = append([]Tile(nil), r...)
+	}
+	bag := append([]Tile(nil), dealtBag...)
+
+	player := me
+	ended := false
+	for ply := 0; ply < opts.Plies; ply++ {
+		move := candidate
+		if ply > 0 {
+			moves := pos.generate(board, racks[player])
+			if len(moves) == 0 {
+				player = (player + 1) % len(racks)
+				continue
+			}
+			opts.Leaves.Rank(board, moves, len(bag))
+			move = moves[0]
+		}
+
+		board = board.WithMove(move)
+		scores[player] += move.Score
+		draw := min(len(move.Placements), len(bag))
+		rack := append(append([]Tile(nil), move.Leave...), bag[:draw]...)
+		bag = bag[draw:]
+		for i := range racks {
+			if pos.sharesRack(i, player) {
+				racks[i] = rack
+			}
+		}
+
+		if len(rack) == 0 {
+			pos.endAdjustment(scores, racks, player)
+			ended = true
+			break
+		}
+		player = (player + 1) % len(racks)
+	}
+
+	// a lone player is measured against an empty score
+	mine := pos.side(me)
+	final, start := pos.sideScores(scores), pos.sideScores(pos.Scores)
+	var best, bestGain int
+	if len(final) > 1 {
+		best = -1 << 31
+	}
+	for side, score := range final {
+		if side == mine {
+			continue
+		}
+		if score > best || (score == best && score-start[side] > bestGain) {
+			best = score
+			bestGain = score - start[side]
+		}
+	}
+	spread := float64(final[mine] - start[mine] - bestGain)
+	margin := float64(final[mine] - best)
+	if !ended {
+		leave := opts.Leaves.Value(racks[me])
+		spread += leave
+		margin += leave
+	}
+
+	switch {
+	case margin > 0:
+		return spread, 1
+	case margin == 0:
+		return spread, 0.5
+	}
+	return spread, 0
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,112 @@
+package scrabble
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// teamPosition sets up an empty board for four players, the first to move holding the rack
+// with the teams given, or each player on their own when teams is nil
+func teamPosition(t *testing.T, scores []int, teams []string, rack, unseen string, words ...string) Position {
+	t.Helper()
+	racks := make([][]Tile, len(scores))
+	var err error
+	racks[0], err = parseSetupTiles(EnglishTiles, rack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unseenTiles, err := parseSetupTiles(EnglishTiles, unseen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Position{
+		Board:      StandardLayout.NewBoard(),
+		Racks:      racks,
+		Scores:     scores,
+		Unseen:     unseenTiles,
+		BagSize:    len(unseenTiles) - 3*HandSize,
+		Dictionary: NewDictionary(words),
+		Rules:      CasualRules,
+		Teams:      teams,
+	}
+}
+
+func TestSimulateCountsPartnersTogether(t *testing.T) {
+	// the partner's points put the team ahead, on their own the player to move trails the partner
+	scores := []int{10, 50, 100, 0}
+	unseen := "EEEEEEEIIIIIIIOOOOOOOUUUUUUU"
+	var spreads []float64
+	for _, tt := range []struct {
+		name  string
+		teams []string
+		win   float64
+	}{
+		{"teams", []string{"x", "y", "x", "y"}, 1},
+		{"individuals", nil, 0},
+	} {
+		pos := teamPosition(t, scores, tt.teams, "CATQQQQ", unseen, "CAT")
+		moves := pos.Moves(DefaultLeaves)
+		if len(moves) == 0 {
+			t.Fatal("no moves found")
+		}
+		results := Simulate(pos, moves[:1], SimOptions{Plies: 1, Iterations: 10, Workers: 1, Seed: 1})
+		if results[0].WinPct != tt.win {
+			t.Errorf("%s: win %v, want %v", tt.name, results[0].WinPct, tt.win)
+		}
+		spreads = append(spreads, results[0].Spread)
+	}
+	// nobody else moves, the spread is the same whoever the player is measured against
+	if spreads[0] != spreads[1] {
+		t.Errorf("spread %v with teams, %v without", spreads[0], spreads[1])
+	}
+}
+
+func TestSampleRacksSharedByPartners(t *testing.T) {
+	pos := teamPosition(t, []int{0, 0, 0, 0}, []string{"x", "y", "x", "y"}, "CATQQQQ", "EEEEEEEIIIIIIIOOOOOOOUUUUUUU")
+	pos.Rules.SharedRack = true
+	racks, bag := pos.sampleRacks(rand.New(rand.NewSource(1)))
+	if tileLetters(racks[2]) != "CATQQQQ" {
+		t.Errorf("partner rack %s, want the rack to move", tileLetters(racks[2]))
+	}
+	if tileLetters(racks[1]) != tileLetters(racks[3]) || len(racks[1]) != HandSize {
+		t.Errorf("opponents hold %s and %s, want one shared rack", tileLetters(racks[1]), tileLetters(racks[3]))
+	}
+	if len(bag) != len(pos.Unseen)-HandSize {
+		t.Errorf("bag has %v tiles, want %v", len(bag), len(pos.Unseen)-HandSize)
+	}
+}
+
+func TestSimulateBudget(t *testing.T) {
+	pos := teamPosition(t, []int{0, 0, 0, 0}, nil, "CATSQQQ", "EEEEEEEIIIIIIIOOOOOOOUUUUUUUCATS", "CAT", "CATS", "AT")
+	candidates := pos.Moves(DefaultLeaves)
+	if len(candidates) < 2 {
+		t.Fatalf("found %v candidates", len(candidates))
+	}
+	opts := SimOptions{Plies: 2, Iterations: 25, Workers: 1, Seed: 3}
+	results := Simulate(pos, candidates, opts)
+	if len(results) != len(candidates) {
+		t.Fatalf("%v results for %v candidates", len(results), len(candidates))
+	}
+	for i, r := range results {
+		if r.Iterations != opts.Iterations {
+			t.Errorf("%s: %v iterations, want %v", r.Move.Notation(), r.Iterations, opts.Iterations)
+		}
+		if i > 0 && (r.WinPct > results[i-1].WinPct || (r.WinPct == results[i-1].WinPct && r.Spread > results[i-1].Spread)) {
+			t.Errorf("%s is ranked below %s", r.Move.Notation(), results[i-1].Move.Notation())
+		}
+	}
+	if again := Simulate(pos, candidates, opts); !reflect.DeepEqual(again, results) {
+		t.Error("the same seed should give the same results")
+	}
+
+	opts.Workers = 4
+	opts.Iterations = 1 << 30
+	opts.Duration = 50 * time.Millisecond
+	start := time.Now()
+	results = Simulate(pos, candidates[:1], opts)
+	if elapsed := time.Since(start); elapsed > 5*time.Second || results[0].Iterations == 0 {
+		t.Errorf("%v iterations in %v, want the duration to end the simulation", results[0].Iterations, elapsed)
+	}
+}
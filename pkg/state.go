@@ -0,0 +1,105 @@
+package scrabble
+
+import (
+	"encoding/json"
+)
+
+// GameState is a snapshot of a game for programs driving it, such as the `--json` mode of the cli
+// @Seed the bag seed, only once the game is finished since it predicts every draw, see Reveal
+// @Board one word per row, `.` for empty squares and lower case for blanks
+// @Rack tiles of the player to move, `_` for a blank
+// @TileSet name of the tile set, letters of several characters are bracketed in the board and rack ([CH])
+// @Over a player has gone out and no more moves can be made
+// @Teams shared scores of the teams, empty when not a team game
+// @Position the position in one line as the player to move sees it, see CGP
+type GameState struct {
+	Game     int64          `json:"game"`
+	Seed     int64          `json:"seed,omitempty"`
+	TileSet  string         `json:"tile_set"`
+	Rules    string         `json:"rules"`
+	Turn     int            `json:"turn"`
+	Board    []string       `json:"board"`
+	Players  []PlayerStatus `json:"players"`
+	Teams    []TeamScore    `json:"teams,omitempty"`
+	ToMove   int            `json:"to_move"`
+	Rack     string         `json:"rack"`
+	Bag      int            `json:"bag"`
+	Actions  []string       `json:"actions"`
+	Over     bool           `json:"over"`
+	Finished bool           `json:"finished"`
+	Position string         `json:"position"`
+}
+
+// PlayerStatus represents a player within a game state
+type PlayerStatus struct {
+	Name   string   `json:"name"`
+	Score  int      `json:"score"`
+	Tiles  int      `json:"tiles"`
+	Bot    BotLevel `json:"bot,omitempty"`
+	Engine string   `json:"engine,omitempty"`
+	Team   string   `json:"team,omitempty"`
+}
+
+// State captures the game from the view of the current player
+func (game *Game) State() GameState {
+	current := game.CurrentPlayer()
+	state := GameState{
+		Game:     game.id,
+		TileSet:  game.TileSet().Name,
+		Rules:    game.Rules().Name,
+		Turn:     len(game.Turns) + 1,
+		Board:    game.board.Rows(),
+		Rack:     tileLetters(current.tiles),
+		Bag:      len(game.Tiles.Remaining),
+		Actions:  game.LegalActions(),
+		Over:     game.IsOver(),
+		Finished: game.finished,
+		Teams:    game.Teams(),
+		Position: game.CGP().Seen().String(),
+	}
+	if game.finished {
+		state.Seed = game.seed()
+	}
+	for i, p := range game.players {
+		if p.Name == current.Name {
+			state.ToMove = i
+		}
+		state.Players = append(state.Players, PlayerStatus{
+			Name:   p.Name,
+			Score:  p.score,
+			Tiles:  len(p.tiles),
+			Bot:    p.Bot,
+			Engine: p.Engine,
+			Team:   p.Team,
+		})
+	}
+	return state
+}
+
+// LegalActions lists the kinds of move open to the current player
+// swapping needs tiles left in the bag, passing is always allowed until the game is over
+func (game *Game) LegalActions() []string {
+	if game.IsOver() {
+		return []string{}
+	}
+	actions := []string{"place"}
+	if game.Rules().canSwap(1, len(game.Tiles.Remaining)) {
+		actions = append(actions, "swap")
+	}
+	return append(actions, "pass")
+}
+
+// MarshalJSON writes the words of a result as plain strings
+func (r Result) MarshalJSON() ([]byte, error) {
+	words := []string{}
+	for _, w := range r.Words {
+		words = append(words, w.String())
+	}
+	return json.Marshal(struct {
+		Action  string   `json:"action"`
+		Words   []string `json:"words"`
+		Score   int      `json:"score"`
+		Swapped int      `json:"swapped"`
+		Invalid []string `json:"invalid,omitempty"`
+	}{r.Action, words, r.Score, r.Swapped, r.Invalid})
+}
@@ -0,0 +1,244 @@
+package scrabble
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// spaced repetition settings, following the SM-2 algorithm
+const (
+	studyEase    = 2.5
+	studyMinEase = 1.3
+)
+
+// StudyOptions picks the questions of a quiz
+// @Length number of letters of the words, 0 for any length
+// @MinRank, @MaxRank bounds on the probability rank among the words of the same length, 0 for no bound
+// @Containing letters of which the words must hold at least one (ex: JQXZ)
+type StudyOptions struct {
+	Length     int
+	MinRank    int
+	MaxRank    int
+	Containing string
+}
+
+// StudyQuestion represents a set of letters to find every anagram of
+// @Alphagram the letters in alphabetical order, letters of several characters are bracketed
+// @Rank 1 for the letters most likely to be drawn among those of the same length
+type StudyQuestion struct {
+	Alphagram string   `json:"alphagram"`
+	Answers   []string `json:"answers"`
+	Rank      int      `json:"rank"`
+}
+
+// StudyResult compares the answers given to a question with its words
+// @Correct every word was found and nothing else was given
+type StudyResult struct {
+	Found   []string `json:"found"`
+	Missed  []string `json:"missed"`
+	Wrong   []string `json:"wrong"`
+	Correct bool     `json:"correct"`
+}
+
+// StudyCard holds the spaced repetition schedule of a question for a user
+// @Ease grows with every correct answer and spaces reviews further apart, never below 1.3
+// @Interval days between the last review and the next, @Due when the question is next asked
+// @Repetitions correct answers in a row
+type StudyCard struct {
+	Alphagram   string    `json:"alphagram"`
+	Ease        float64   `json:"ease"`
+	Interval    int       `json:"interval"`
+	Repetitions int       `json:"repetitions"`
+	Due         time.Time `json:"due"`
+	Attempts    int       `json:"attempts"`
+	Correct     int       `json:"correct"`
+}
+
+// StudySet builds the questions matching the options, the most probable first
+// the probability of a set of letters is the number of ways to draw it from the tile set, blanks aside
+func (d Dictionary) StudySet(opts StudyOptions) []StudyQuestion {
+	ts := d.TileSet()
+	containing := ts.Split(strings.ToUpper(opts.Containing))
+
+	questions := make(map[string]*StudyQuestion)
+	ways := make(map[string]float64)
+	lengths := make(map[string]int)
+	for word := range d.Words {
+		letters := ts.Split(word)
+		if opts.Length > 0 && len(letters) != opts.Length {
+			continue
+		}
+		sort.Strings(letters)
+		key := alphagram(letters)
+		q, ok := questions[key]
+		if !ok {
+			q = &StudyQuestion{Alphagram: key}
+			questions[key] = q
+			ways[key] = drawWays(ts, letters)
+			lengths[key] = len(letters)
+		}
+		q.Answers = append(q.Answers, word)
+	}
+
+	var set []StudyQuestion
+	for _, q := range questions {
+		sort.Strings(q.Answers)
+		set = append(set, *q)
+	}
+	sort.Slice(set, func(i, j int) bool {
+		a, b := set[i].Alphagram, set[j].Alphagram
+		if lengths[a] != lengths[b] {
+			return lengths[a] < lengths[b]
+		}
+		if ways[a] != ways[b] {
+			return ways[a] > ways[b]
+		}
+		return a < b
+	})
+
+	var matches []StudyQuestion
+	for i := range set {
+		set[i].Rank = 1
+		if i > 0 && lengths[set[i-1].Alphagram] == lengths[set[i].Alphagram] {
+			set[i].Rank = set[i-1].Rank + 1
+		}
+		q := set[i]
+		if (opts.MinRank > 0 && q.Rank < opts.MinRank) || (opts.MaxRank > 0 && q.Rank > opts.MaxRank) {
+			continue
+		}
+		if len(containing) > 0 && !containsAny(ts.Split(q.Answers[0]), containing) {
+			continue
+		}
+		matches = append(matches, q)
+	}
+	return matches
+}
+
+// Grade checks the words given for a question, in any case and order
+func (q StudyQuestion) Grade(answers []string) StudyResult {
+	given := make(map[string]bool)
+	for _, a := range answers {
+		given[strings.ToUpper(strings.TrimSpace(a))] = true
+	}
+	delete(given, "")
+
+	var result StudyResult
+	for _, w := range q.Answers {
+		if given[w] {
+			result.Found = append(result.Found, w)
+			delete(given, w)
+		} else {
+			result.Missed = append(result.Missed, w)
+		}
+	}
+	for w := range given {
+		result.Wrong = append(result.Wrong, w)
+	}
+	sort.Strings(result.Wrong)
+	result.Correct = len(result.Missed) == 0 && len(result.Wrong) == 0
+	return result
+}
+
+// NewStudyCard schedules a question never asked before, due straight away
+func NewStudyCard(alphagram string, now time.Time) StudyCard {
+	return StudyCard{Alphagram: alphagram, Ease: studyEase, Due: now}
+}
+
+// Review reschedules the card after an answer
+// a correct answer is next asked after 1 day, then 6, then the last interval times the ease
+// a wrong answer starts the question over the next day and lowers its ease
+func (c StudyCard) Review(correct bool, now time.Time) StudyCard {
+	c.Attempts++
+	if correct {
+		c.Correct++
+		c.Repetitions++
+		switch c.Repetitions {
+		case 1:
+			c.Interval = 1
+		case 2:
+			c.Interval = 6
+		default:
+			c.Interval = int(math.Round(float64(c.Interval) * c.Ease))
+		}
+		c.Ease += 0.1
+	} else {
+		c.Repetitions = 0
+		c.Interval = 1
+		c.Ease = math.Max(studyMinEase, c.Ease-0.2)
+	}
+	c.Due = now.AddDate(0, 0, c.Interval)
+	return c
+}
+
+// ScheduleStudy picks up to count questions of the set to ask now
+// questions due for review come first, the longest overdue first, then questions never asked in set order
+func ScheduleStudy(set []StudyQuestion, cards map[string]StudyCard, now time.Time, count int) []StudyQuestion {
+	var due, fresh []StudyQuestion
+	for _, q := range set {
+		card, ok := cards[q.Alphagram]
+		switch {
+		case !ok:
+			fresh = append(fresh, q)
+		case !card.Due.After(now):
+			due = append(due, q)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return cards[due[i].Alphagram].Due.Before(cards[due[j].Alphagram].Due)
+	})
+
+	scheduled := append(due, fresh...)
+	if count > 0 && len(scheduled) > count {
+		scheduled = scheduled[:count]
+	}
+	return scheduled
+}
+
+// alphagram writes sorted letters as a single row, bracketing letters of several characters
+func alphagram(letters []string) string {
+	var b strings.Builder
+	for _, l := range letters {
+		b.WriteString(writtenLetter(l))
+	}
+	return b.String()
+}
+
+// drawWays counts the ways the letters can be drawn from the tiles of the set
+func drawWays(ts TileSet, letters []string) float64 {
+	ways := 1.0
+	for i := 0; i < len(letters); {
+		j := i
+		for j < len(letters) && letters[j] == letters[i] {
+			j++
+		}
+		ways *= binomial(ts.Counts[letters[i]], j-i)
+		i = j
+	}
+	return ways
+}
+
+// binomial counts the ways to choose k of n
+func binomial(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	ways := 1.0
+	for i := 0; i < k; i++ {
+		ways = ways * float64(n-i) / float64(i+1)
+	}
+	return ways
+}
+
+// containsAny checks whether any of the wanted letters is among the letters
+func containsAny(letters, wanted []string) bool {
+	for _, l := range letters {
+		for _, w := range wanted {
+			if l == w {
+				return true
+			}
+		}
+	}
+	return false
+}
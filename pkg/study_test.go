@@ -0,0 +1,126 @@
+package scrabble
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStudySet(t *testing.T) {
+	dict := NewDictionary([]string{"AT", "TA", "QI", "ZA", "EAT", "TEA", "ATE", "ZAX"})
+	tests := []struct {
+		name string
+		opts StudyOptions
+		want []string
+	}{
+		{"everything", StudyOptions{}, []string{"AT", "AZ", "IQ", "AET", "AXZ"}},
+		{"twos", StudyOptions{Length: 2}, []string{"AT", "AZ", "IQ"}},
+		{"most probable", StudyOptions{MaxRank: 1}, []string{"AT", "AET"}},
+		{"least probable twos", StudyOptions{Length: 2, MinRank: 2}, []string{"AZ", "IQ"}},
+		{"power tiles", StudyOptions{Containing: "jqxz"}, []string{"AZ", "IQ", "AXZ"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, q := range dict.StudySet(tt.opts) {
+			got = append(got, q.Alphagram)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	set := dict.StudySet(StudyOptions{Length: 3})
+	if !reflect.DeepEqual(set[0], StudyQuestion{Alphagram: "AET", Answers: []string{"ATE", "EAT", "TEA"}, Rank: 1}) {
+		t.Errorf("first question %+v", set[0])
+	}
+	result := set[0].Grade([]string{"eat", " tea ", "ETA", ""})
+	if result.Correct || !reflect.DeepEqual(result.Found, []string{"EAT", "TEA"}) || !reflect.DeepEqual(result.Missed, []string{"ATE"}) || !reflect.DeepEqual(result.Wrong, []string{"ETA"}) {
+		t.Errorf("graded %+v", result)
+	}
+	if result := set[0].Grade([]string{"TEA", "ATE", "EAT"}); !result.Correct {
+		t.Errorf("every anagram in any order should be correct, got %+v", result)
+	}
+}
+
+func TestStudyCardReview(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	card := NewStudyCard("AET", now)
+	tests := []struct {
+		correct  bool
+		interval int
+		ease     float64
+	}{
+		{true, 1, 2.6},
+		{true, 6, 2.7},
+		{true, 16, 2.8},
+		{false, 1, 2.6},
+		{true, 1, 2.7},
+	}
+	for i, tt := range tests {
+		card = card.Review(tt.correct, now)
+		if card.Interval != tt.interval || math.Abs(card.Ease-tt.ease) > 1e-9 || !card.Due.Equal(now.AddDate(0, 0, tt.interval)) {
+			t.Errorf("review %v: interval %v ease %v due %v, want %v and %v", i+1, card.Interval, card.Ease, card.Due, tt.interval, tt.ease)
+		}
+	}
+	if card.Attempts != 5 || card.Correct != 4 || card.Repetitions != 1 {
+		t.Errorf("card %+v", card)
+	}
+	for i := 0; i < 10; i++ {
+		card = card.Review(false, now)
+	}
+	if card.Ease != studyMinEase {
+		t.Errorf("ease %v after many wrong answers, want %v", card.Ease, studyMinEase)
+	}
+}
+
+func TestScheduleStudy(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	set := []StudyQuestion{{Alphagram: "A"}, {Alphagram: "B"}, {Alphagram: "C"}, {Alphagram: "D"}, {Alphagram: "E"}}
+	cards := map[string]StudyCard{
+		"A": {Alphagram: "A", Due: now.Add(time.Hour)},
+		"B": {Alphagram: "B", Due: now.Add(-time.Hour)},
+		"D": {Alphagram: "D", Due: now.AddDate(0, 0, -2)},
+	}
+	var got []string
+	for _, q := range ScheduleStudy(set, cards, now, 3) {
+		got = append(got, q.Alphagram)
+	}
+	// overdue questions, the longest overdue first, then new ones, never those not yet due
+	if want := []string{"D", "B", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("scheduled %v, want %v", got, want)
+	}
+}
+
+func TestStudyCardsSaved(t *testing.T) {
+	gameDB := newTestDB(t)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	card := NewStudyCard("AET", now).Review(true, now)
+	for _, c := range []StudyCard{NewStudyCard("AET", now), card, NewStudyCard("AZ", now)} {
+		if err := gameDB.SaveStudyCard("carol", "english", c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := gameDB.SaveStudyCard("carol", "spanish", NewStudyCard("AOS", now)); err != nil {
+		t.Fatal(err)
+	}
+
+	cards, err := gameDB.StudyCards("carol", "english")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("read %v cards, want the two english cards", len(cards))
+	}
+	loaded := cards["AET"]
+	if !loaded.Due.Equal(card.Due) {
+		t.Errorf("due %v, want %v", loaded.Due, card.Due)
+	}
+	loaded.Due = card.Due
+	if loaded != card {
+		t.Errorf("read %+v, saved %+v", loaded, card)
+	}
+	if others, _ := gameDB.StudyCards("dave", "english"); len(others) != 0 {
+		t.Errorf("dave has %v cards", len(others))
+	}
+}
@@ -0,0 +1,192 @@
+package scrabble
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TeamScore represents the partners of a team and the score they share
+// @Players names of the partners in turn order
+// @Score points of every partner added together
+// @HighestWord, @HighestScore best single play by any of the partners
+type TeamScore struct {
+	Name         string   `json:"name"`
+	Players      []string `json:"players"`
+	Score        int      `json:"score"`
+	HighestWord  string   `json:"highest_word,omitempty"`
+	HighestScore int      `json:"highest_score,omitempty"`
+}
+
+func (t TeamScore) String() string {
+	return fmt.Sprintf("%s (%s): %v", t.Name, strings.Join(t.Players, ", "), t.Score)
+}
+
+// IsTeamGame checks whether the players are split into teams
+func (game Game) IsTeamGame() bool {
+	return len(game.players) > 0 && game.players[0].Team != ""
+}
+
+// Teams returns the score of every team in the order the teams take turns, nil when not a team game
+// each partner keeps their own score, the team is credited with all of them
+func (game Game) Teams() []TeamScore {
+	if !game.IsTeamGame() {
+		return nil
+	}
+	var teams []TeamScore
+	index := make(map[string]int)
+	for _, p := range game.players {
+		i, ok := index[p.Team]
+		if !ok {
+			i = len(teams)
+			index[p.Team] = i
+			teams = append(teams, TeamScore{Name: p.Team})
+		}
+		team := &teams[i]
+		team.Players = append(team.Players, p.Name)
+		team.Score += p.score
+		if p.highestScore > team.HighestScore {
+			team.HighestWord, team.HighestScore = p.highestWord, p.highestScore
+		}
+	}
+	return teams
+}
+
+// WinningTeam finds the team with the highest shared score
+// no team wins when the best score is shared or when no team scored above zero
+func (game Game) WinningTeam() TeamScore {
+	top, leaders := game.leaders()
+	if leaders != 1 || top <= 0 {
+		return TeamScore{}
+	}
+	for _, t := range game.Teams() {
+		if t.Score == top {
+			return t
+		}
+	}
+	return TeamScore{}
+}
+
+// Partners returns the other players of the player's team
+func (game Game) Partners(player Player) []Player {
+	var partners []Player
+	if player.Team == "" {
+		return partners
+	}
+	for _, p := range game.players {
+		if p.Team == player.Team && p.id != player.id {
+			partners = append(partners, p)
+		}
+	}
+	return partners
+}
+
+// ValidateTeams checks the players are either all without a team or split into teams of the same size
+func ValidateTeams(requests []PlayerRequest) error {
+	_, err := seatTeams(requests)
+	return err
+}
+
+// seatTeams checks the requests form teams of the same size and seats them so the teams alternate
+// teams take turns in the order they first appear, partners in the order they are listed
+// requests without any team are returned as they are
+func seatTeams(requests []PlayerRequest) ([]PlayerRequest, error) {
+	var names []string
+	members := make(map[string][]PlayerRequest)
+	for _, r := range requests {
+		if r.Team == "" {
+			continue
+		}
+		if _, ok := members[r.Team]; !ok {
+			names = append(names, r.Team)
+		}
+		members[r.Team] = append(members[r.Team], r)
+	}
+	if len(names) == 0 {
+		return requests, nil
+	}
+
+	size := len(members[names[0]])
+	if len(names) < 2 || size*len(names) != len(requests) {
+		return nil, ErrTeams
+	}
+	for _, name := range names {
+		if len(members[name]) != size {
+			return nil, ErrTeams
+		}
+	}
+
+	seated := make([]PlayerRequest, 0, len(requests))
+	for i := 0; i < size; i++ {
+		for _, name := range names {
+			seated = append(seated, members[name][i])
+		}
+	}
+	return seated, nil
+}
+
+// dealRack draws the opening rack of a player, partners sharing a rack are given a copy of it
+func (game *Game) dealRack(team string) []Tile {
+	if team != "" && game.Rules().SharedRack {
+		for _, p := range game.players {
+			if p.Team == team {
+				return append([]Tile(nil), p.tiles...)
+			}
+		}
+	}
+	return game.Draw(game.Rules().RackSize)
+}
+
+// shareRack hands the rack of the current player on to the partners sharing it
+func (game *Game) shareRack() {
+	if !game.Rules().SharedRack {
+		return
+	}
+	// the turn holds the player as they were before the move, the players hold the rack after it
+	var current Player
+	for _, p := range game.players {
+		if p.id == game.CurrentPlayer().id {
+			current = p
+		}
+	}
+	for _, p := range game.Partners(current) {
+		p.tiles = append([]Tile(nil), current.tiles...)
+		game.SetPlayerState(p)
+	}
+}
+
+// playerTeams returns the team of every player in turn order, nil when not a team game
+func (game Game) playerTeams() []string {
+	if !game.IsTeamGame() {
+		return nil
+	}
+	teams := make([]string, len(game.players))
+	for i, p := range game.players {
+		teams[i] = p.Team
+	}
+	return teams
+}
+
+// endTeamAdjustment applies the end of game scoring to teams, teams holds the team of every player
+// every player loses the value of their rack, a player who went out gains the racks of the other teams
+// racks shared by partners are only counted once
+func endTeamAdjustment(scores []int, racks [][]Tile, teams []string, shared bool, out int) {
+	counted := make(map[string]bool)
+	values := make(map[string]int)
+	for i, rack := range racks {
+		if shared && counted[teams[i]] {
+			continue
+		}
+		counted[teams[i]] = true
+		value := rackValue(rack)
+		scores[i] -= value
+		values[teams[i]] += value
+	}
+	if out < 0 {
+		return
+	}
+	for team, value := range values {
+		if team != teams[out] {
+			scores[out] += value
+		}
+	}
+}
@@ -1,15 +1,50 @@
 package scrabble
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"sort"
 )
 
 const shuffleLoop = 20
 
 // Tiles are a collection of tiles stored by the game object
+// @seed seeds every shuffle of the bag so draws can be replayed, it predicts every draw
+// so it is only given out by Game.Reveal and saved in its own column, never with the bag
+// @Shuffles counts the shuffles performed, each one derives its own source from the seed
+// @Ordered indicates a pre-ordered bag that is drawn front to back without shuffling
+// @Set name of the tile set the bag was filled from, english when empty
 type Tiles struct {
 	Remaining []Tile
+	seed      int64
+	Shuffles  int64
+	Ordered   bool
+	Set       string
+}
+
+// savedTiles is the json form of a bag, every field but the seed
+type savedTiles struct {
+	Remaining []Tile
+	Shuffles  int64
+	Ordered   bool
+	Set       string `json:",omitempty"`
+}
+
+// MarshalJSON writes the bag without its seed
+func (t Tiles) MarshalJSON() ([]byte, error) {
+	return json.Marshal(savedTiles{Remaining: t.Remaining, Shuffles: t.Shuffles, Ordered: t.Ordered, Set: t.Set})
+}
+
+// UnmarshalJSON reads a bag written by MarshalJSON, the seed is restored separately
+// a seed saved with the bag by older versions is ignored, the games table holds it too
+func (t *Tiles) UnmarshalJSON(data []byte) error {
+	var saved savedTiles
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	t.Remaining, t.Shuffles, t.Ordered, t.Set = saved.Remaining, saved.Shuffles, saved.Ordered, saved.Set
+	return nil
 }
 
 // Tile a representation of tiles played on the scrabble board
@@ -27,29 +62,84 @@ func (t Tile) String() string {
 }
 
 // InitializeTiles sets up the tile bag and then shuffles the entries
+// The bag is seeded with a random seed, see InitializeSeededTiles
 func InitializeTiles() Tiles {
-	tiles := Tiles{}
-	tiles.initializeTiles()
+	return InitializeSeededTiles(rand.Int63())
+}
+
+// InitializeSeededTiles sets up the tile bag and shuffles it using the provided seed
+// the same seed and sequence of draws/returns will always produce the same tiles
+func InitializeSeededTiles(seed int64) Tiles {
+	return InitializeTileSet(EnglishTiles, seed)
+}
+
+// InitializeTileSet sets up a bag with the distribution of the tile set, shuffled using the seed
+func InitializeTileSet(ts TileSet, seed int64) Tiles {
+	tiles := Tiles{seed: seed}
+	if ts.Name != EnglishTiles.Name {
+		tiles.Set = ts.Name
+	}
+	tiles.initializeTiles(ts)
 	tiles.shuffle()
 	return tiles
 }
 
-func (t *Tiles) initializeTiles() {
-	for letter, count := range MapLetterToCount {
-		for i := 0; i < count; i++ {
-			t.Remaining = append(t.Remaining, getTile(letter))
+// NewOrderedTiles creates a bag that is drawn in exactly the provided order
+// used for scripted scenarios where every draw needs to be known ahead of time
+func NewOrderedTiles(order []Tile) Tiles {
+	remaining := make([]Tile, len(order))
+	copy(remaining, order)
+	return Tiles{
+		Remaining: remaining,
+		Ordered:   true,
+	}
+}
+
+func (t *Tiles) initializeTiles(ts TileSet) {
+	// map iteration order is random, sort letters so a seed always yields the same bag
+	var letters []string
+	for letter := range ts.Counts {
+		letters = append(letters, letter)
+	}
+	sort.Strings(letters)
+
+	for _, letter := range letters {
+		for i := 0; i < ts.Counts[letter]; i++ {
+			t.Remaining = append(t.Remaining, ts.Tile(letter))
 		}
 	}
 }
 
+// TileSet returns the tile set the bag was filled from
+func (t Tiles) TileSet() TileSet {
+	if ts, ok := TileSets[t.Set]; ok {
+		return ts
+	}
+	return EnglishTiles
+}
+
 func (t *Tiles) shuffle() {
+	if t.Ordered {
+		return
+	}
+	// every shuffle gets its own source so the bag can be persisted and resumed
+	rng := rand.New(rand.NewSource(mixSeed(t.seed, t.Shuffles)))
+	t.Shuffles++
 	for rep := 0; rep < shuffleLoop; rep++ {
-		rand.Shuffle(len(t.Remaining), func(i, j int) {
+		rng.Shuffle(len(t.Remaining), func(i, j int) {
 			t.Remaining[i], t.Remaining[j] = t.Remaining[j], t.Remaining[i]
 		})
 	}
 }
 
+// mixSeed combines a seed and a counter into a well distributed source seed (splitmix64)
+func mixSeed(seed, n int64) int64 {
+	z := uint64(seed) + uint64(n+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
+
 // GetTiles returns all of the remaining tiles
 func (t *Tiles) GetTiles() []Tile {
 	return t.Remaining
@@ -61,7 +151,9 @@ func (t *Tiles) Draw(numDraw int) []Tile {
 	if numDraw > len(t.Remaining) {
 		numDraw = len(t.Remaining)
 	}
-	tiles := t.Remaining[0:numDraw]
+	// copy so racks never share memory with the bag
+	tiles := make([]Tile, numDraw)
+	copy(tiles, t.Remaining[0:numDraw])
 	t.Remaining = t.Remaining[numDraw:]
 	return tiles
 }
@@ -71,14 +163,7 @@ func (t *Tiles) Return(tiles []Tile) {
 	t.Remaining = append(t.Remaining, tiles...)
 }
 
-func getTile(letter string) Tile {
-	return Tile{
-		Letter: letter,
-		Value:  MapLetterToValue[letter],
-	}
-}
-
-// MapLetterToCount maps the string to number of each tile for the board
+// MapLetterToCount maps the string to number of each tile for the board, see EnglishTiles
 var MapLetterToCount = map[string]int{
 	"A": 9,
 	"B": 2,
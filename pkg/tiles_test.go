@@ -0,0 +1,64 @@
+package scrabble
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSeededGamesDrawTheSameTiles(t *testing.T) {
+	dict := NewDictionary([]string{"CAT"})
+	newGame := func(seed int64) *Game {
+		return NewGameWithOptions([]PlayerRequest{{Name: "alice"}, {Name: "bob"}}, GameOptions{Seed: seed, Dictionary: &dict, FixedOrder: true}, nil)
+	}
+	first, second := newGame(5), newGame(5)
+	if reflect.DeepEqual(first.Tiles.Remaining, newGame(6).Tiles.Remaining) {
+		t.Error("games from different seeds should fill their bags differently")
+	}
+
+	// swaps return tiles to the bag and shuffle it again, the same swaps must draw the same tiles
+	for turn := 0; turn < 6; turn++ {
+		for i := range first.players {
+			if !reflect.DeepEqual(first.players[i].Tiles(), second.players[i].Tiles()) {
+				t.Fatalf("turn %v: %s holds %v and %v", turn, first.players[i].Name, first.players[i].Tiles(), second.players[i].Tiles())
+			}
+		}
+		if !reflect.DeepEqual(first.Tiles.Remaining, second.Tiles.Remaining) {
+			t.Fatalf("turn %v: the bags differ", turn)
+		}
+
+		rack := first.CurrentPlayer().Tiles()
+		var letters []string
+		for _, tile := range rack[:turn%3+1] {
+			letters = append(letters, tile.Letter)
+		}
+		input := "swap " + strings.Join(letters, " ")
+		for _, game := range []*Game{first, second} {
+			if _, err := game.ApplyTurn(input, nil); err != nil {
+				t.Fatalf("turn %v: %s: %v", turn, input, err)
+			}
+		}
+	}
+}
+
+func TestOrderedBagDrawsInOrder(t *testing.T) {
+	order := EnglishTiles.parseTiles(strings.Split("CATSDOGEEIRNTSQ", ""))
+	bag := NewOrderedTiles(order)
+	order[0] = EnglishTiles.Tile("Z")
+	if bag.Remaining[0].Letter != "C" {
+		t.Error("the bag should not share the order given")
+	}
+	if drawn := tileLetters(bag.Draw(4)); drawn != "CATS" {
+		t.Errorf("drew %s, want CATS", drawn)
+	}
+	bag.Return(EnglishTiles.parseTiles([]string{"X"}))
+	if drawn := tileLetters(bag.Draw(20)); drawn != "DOGEEIRNTSQX" {
+		t.Errorf("drew %s, want the rest of the bag then the returned X", drawn)
+	}
+
+	game := NewGameWithOptions([]PlayerRequest{{Name: "alice"}, {Name: "bob"}},
+		GameOptions{Seed: 1, Bag: order[1:], Dictionary: &Dictionary{}, FixedOrder: true}, nil)
+	if alice, bob := tileLetters(game.players[0].Tiles()), tileLetters(game.players[1].Tiles()); alice != "ATSDOGE" || bob != "EIRNTSQ" {
+		t.Errorf("alice holds %s and bob %s, want the bag dealt in order", alice, bob)
+	}
+}
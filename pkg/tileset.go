@@ -0,0 +1,273 @@
+package scrabble
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// TileSet represents the tile distribution of a language
+// @Counts number of each tile in the bag, blanks are counted under "_"
+// @Values points scored by each tile, a letter may be written with several characters (CH, LL, RR)
+// @Vowels letters counted as vowels when balancing racks
+// @LeaveValues worth of keeping each tile used by the leave heuristic, only blanks are valued when nil
+// @Dictionary word list loaded for games using the tile set
+type TileSet struct {
+	Name        string
+	Counts      map[string]int
+	Values      map[string]int
+	Vowels      map[string]bool
+	LeaveValues map[string]float64
+	Dictionary  string
+}
+
+// EnglishTiles is the standard english distribution of 100 tiles
+var EnglishTiles = TileSet{
+	Name:        "english",
+	Counts:      MapLetterToCount,
+	Values:      MapLetterToValue,
+	Vowels:      vowels,
+	LeaveValues: singleLeaveValues,
+	Dictionary:  dictPath,
+}
+
+// SpanishTiles is the spanish distribution of 100 tiles, CH, LL and RR are single tiles
+var SpanishTiles = TileSet{
+	Name: "spanish",
+	Counts: map[string]int{
+		"A": 12, "B": 2, "C": 4, "CH": 1, "D": 5, "E": 12, "F": 1, "G": 2, "H": 2, "I": 6,
+		"J": 1, "L": 4, "LL": 1, "M": 2, "N": 5, "Ñ": 1, "O": 9, "P": 2, "Q": 1, "R": 5,
+		"RR": 1, "S": 6, "T": 4, "U": 5, "V": 1, "X": 1, "Y": 1, "Z": 1, "_": 2,
+	},
+	Values: map[string]int{
+		"A": 1, "B": 3, "C": 3, "CH": 5, "D": 2, "E": 1, "F": 4, "G": 2, "H": 4, "I": 1,
+		"J": 8, "L": 1, "LL": 8, "M": 3, "N": 1, "Ñ": 8, "O": 1, "P": 3, "Q": 5, "R": 1,
+		"RR": 8, "S": 1, "T": 1, "U": 1, "V": 4, "X": 8, "Y": 4, "Z": 10, "_": 0,
+	},
+	Vowels:     map[string]bool{"A": true, "E": true, "I": true, "O": true, "U": true},
+	Dictionary: "data/spanish.txt",
+}
+
+// FrenchTiles is the french distribution of 102 tiles, accents are not written on tiles
+var FrenchTiles = TileSet{
+	Name: "french",
+	Counts: map[string]int{
+		"A": 9, "B": 2, "C": 2, "D": 3, "E": 15, "F": 2, "G": 2, "H": 2, "I": 8, "J": 1,
+		"K": 1, "L": 5, "M": 3, "N": 6, "O": 6, "P": 2, "Q": 1, "R": 6, "S": 6, "T": 6,
+		"U": 6, "V": 2, "W": 1, "X": 1, "Y": 1, "Z": 1, "_": 2,
+	},
+	Values: map[string]int{
+		"A": 1, "B": 3, "C": 3, "D": 2, "E": 1, "F": 4, "G": 2, "H": 4, "I": 1, "J": 8,
+		"K": 10, "L": 1, "M": 2, "N": 1, "O": 1, "P": 3, "Q": 8, "R": 1, "S": 1, "T": 1,
+		"U": 1, "V": 4, "W": 10, "X": 10, "Y": 10, "Z": 10, "_": 0,
+	},
+	Vowels:     map[string]bool{"A": true, "E": true, "I": true, "O": true, "U": true, "Y": true},
+	Dictionary: "data/french.txt",
+}
+
+// GermanTiles is the german distribution of 102 tiles including Ä, Ö and Ü
+var GermanTiles = TileSet{
+	Name: "german",
+	Counts: map[string]int{
+		"A": 5, "Ä": 1, "B": 2, "C": 2, "D": 4, "E": 15, "F": 2, "G": 3, "H": 4, "I": 6,
+		"J": 1, "K": 2, "L": 3, "M": 4, "N": 9, "O": 3, "Ö": 1, "P": 1, "Q": 1, "R": 6,
+		"S": 7, "T": 6, "U": 6, "Ü": 1, "V": 1, "W": 1, "X": 1, "Y": 1, "Z": 1, "_": 2,
+	},
+	Values: map[string]int{
+		"A": 1, "Ä": 6, "B": 3, "C": 4, "D": 1, "E": 1, "F": 4, "G": 2, "H": 2, "I": 1,
+		"J": 6, "K": 4, "L": 2, "M": 3, "N": 1, "O": 2, "Ö": 8, "P": 4, "Q": 10, "R": 1,
+		"S": 1, "T": 1, "U": 1, "Ü": 6, "V": 6, "W": 3, "X": 8, "Y": 10, "Z": 3, "_": 0,
+	},
+	Vowels: map[string]bool{
+		"A": true, "Ä": true, "E": true, "I": true, "O": true, "Ö": true, "U": true, "Ü": true,
+	},
+	Dictionary: "data/german.txt",
+}
+
+// DutchTiles is the dutch distribution of 102 tiles
+var DutchTiles = TileSet{
+	Name: "dutch",
+	Counts: map[string]int{
+		"A": 6, "B": 2, "C": 2, "D": 5, "E": 18, "F": 2, "G": 3, "H": 2, "I": 4, "J": 2,
+		"K": 3, "L": 3, "M": 3, "N": 10, "O": 6, "P": 2, "Q": 1, "R": 5, "S": 5, "T": 5,
+		"U": 3, "V": 2, "W": 2, "X": 1, "Y": 1, "Z": 2, "_": 2,
+	},
+	Values: map[string]int{
+		"A": 1, "B": 3, "C": 5, "D": 2, "E": 1, "F": 4, "G": 3, "H": 4, "I": 1, "J": 4,
+		"K": 3, "L": 3, "M": 3, "N": 1, "O": 1, "P": 3, "Q": 10, "R": 2, "S": 2, "T": 2,
+		"U": 4, "V": 4, "W": 5, "X": 8, "Y": 8, "Z": 4, "_": 0,
+	},
+	Vowels:     map[string]bool{"A": true, "E": true, "I": true, "O": true, "U": true},
+	Dictionary: "data/dutch.txt",
+}
+
+// FriendsTiles is the english distribution of 104 tiles in the style of Words With Friends
+var FriendsTiles = TileSet{
+	Name: "friends",
+	Counts: map[string]int{
+		"A": 9, "B": 2, "C": 2, "D": 5, "E": 13, "F": 2, "G": 3, "H": 4, "I": 8, "J": 1,
+		"K": 1, "L": 4, "M": 2, "N": 5, "O": 8, "P": 2, "Q": 1, "R": 6, "S": 5, "T": 7,
+		"U": 4, "V": 2, "W": 2, "X": 1, "Y": 2, "Z": 1, "_": 2,
+	},
+	Values: map[string]int{
+		"A": 1, "B": 4, "C": 4, "D": 2, "E": 1, "F": 4, "G": 3, "H": 3, "I": 1, "J": 10,
+		"K": 5, "L": 2, "M": 4, "N": 2, "O": 1, "P": 4, "Q": 10, "R": 1, "S": 1, "T": 1,
+		"U": 2, "V": 5, "W": 4, "X": 8, "Y": 3, "Z": 10, "_": 0,
+	},
+	Vowels:      vowels,
+	LeaveValues: singleLeaveValues,
+	Dictionary:  dictPath,
+}
+
+// TileSets maps names to the built in tile sets, used for parsing player input
+var TileSets = map[string]TileSet{
+	EnglishTiles.Name: EnglishTiles,
+	SpanishTiles.Name: SpanishTiles,
+	FrenchTiles.Name:  FrenchTiles,
+	GermanTiles.Name:  GermanTiles,
+	DutchTiles.Name:   DutchTiles,
+	FriendsTiles.Name: FriendsTiles,
+}
+
+// FindTileSet returns the built in tile set with the given name, english when the name is empty
+func FindTileSet(name string) (TileSet, error) {
+	if name == "" {
+		return EnglishTiles, nil
+	}
+	ts, ok := TileSets[strings.ToLower(name)]
+	if !ok {
+		return TileSet{}, ErrUnknownTileSet{Name: name}
+	}
+	return ts, nil
+}
+
+// TileSetNames lists the built in tile sets alphabetically
+func TileSetNames() []string {
+	var names []string
+	for name := range TileSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Size returns the number of tiles in a full bag
+func (ts TileSet) Size() int {
+	var size int
+	for _, count := range ts.Counts {
+		size += count
+	}
+	return size
+}
+
+// Tile returns the tile of the set for the letter
+func (ts TileSet) Tile(letter string) Tile {
+	return Tile{
+		Letter: letter,
+		Value:  ts.Values[letter],
+	}
+}
+
+// Split breaks a word into the letters of individual tiles
+// the longest letter of the set is taken first, so CHICO is CH I C O with spanish tiles
+// characters outside of the set are kept as letters of their own
+func (ts TileSet) Split(word string) []string {
+	return splitWord(word, ts.multiLetters())
+}
+
+// splitWord breaks a word into letters given the letters written with several characters
+func splitWord(word string, multi []string) []string {
+	letters := make([]string, 0, len(word))
+	for word != "" {
+		letter := firstLetter(word, multi)
+		letters = append(letters, letter)
+		word = word[len(letter):]
+	}
+	return letters
+}
+
+// multiLetters lists the letters written with more than one character, longest first
+func (ts TileSet) multiLetters() []string {
+	var multi []string
+	for letter := range ts.Counts {
+		if utf8.RuneCountInString(letter) > 1 {
+			multi = append(multi, letter)
+		}
+	}
+	sort.Slice(multi, func(i, j int) bool {
+		return len(multi[i]) > len(multi[j])
+	})
+	return multi
+}
+
+// firstLetter returns the letter starting the text
+func firstLetter(text string, multi []string) string {
+	for _, letter := range multi {
+		if strings.HasPrefix(text, letter) {
+			return letter
+		}
+	}
+	_, n := utf8.DecodeRuneInString(text)
+	return text[:n]
+}
+
+// splitInput breaks player input into letters ignoring case
+// a letter written with several characters must use a single case, Ch is C then H
+// brackets keep letters apart as they are written in board rows, [C][H] is C then H
+// bracketed letters are returned with their brackets, see unbracket
+func (ts TileSet) splitInput(text string) []string {
+	multi := ts.multiLetters()
+	var letters []string
+	for text != "" {
+		if end := strings.Index(text, "]"); text[0] == '[' && end > 1 {
+			letters = append(letters, text[:end+1])
+			text = text[end+1:]
+			continue
+		}
+		letter := firstLetter(text, nil)
+		for _, m := range multi {
+			n := len(m)
+			if n <= len(text) && strings.ToUpper(text[:n]) == m && sameCase(text[:n]) {
+				letter = text[:n]
+				break
+			}
+		}
+		letters = append(letters, letter)
+		text = text[len(letter):]
+	}
+	return letters
+}
+
+// unbracket removes the brackets around a letter
+func unbracket(letter string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(letter, "["), "]")
+}
+
+// sameCase checks every letter of the text is either upper or lower case
+func sameCase(text string) bool {
+	return strings.ToUpper(text) == text || strings.ToLower(text) == text
+}
+
+// writtenLetter brackets letters of several characters so a row of letters splits unambiguously
+func writtenLetter(letter string) string {
+	if utf8.RuneCountInString(letter) > 1 {
+		return "[" + letter + "]"
+	}
+	return letter
+}
+
+// parseTiles converts letters given by a player into tiles of the set
+func (ts TileSet) parseTiles(tokens []string) []Tile {
+	var tiles []Tile
+	for _, t := range tokens {
+		letter := strings.ToUpper(t)
+		tiles = append(tiles, ts.Tile(letter))
+	}
+
+	return tiles
+}
+
+func (ts TileSet) String() string {
+	return fmt.Sprintf("%s (%v tiles)", ts.Name, ts.Size())
+}
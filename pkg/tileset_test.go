@@ -0,0 +1,93 @@
+package scrabble
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTileSetDistributions(t *testing.T) {
+	sizes := map[string]int{"english": 100, "spanish": 100, "french": 102, "german": 102, "dutch": 102, "friends": 104}
+	for name, ts := range TileSets {
+		if ts.Size() != sizes[name] {
+			t.Errorf("%s: %v tiles, want %v", name, ts.Size(), sizes[name])
+		}
+		if len(ts.Counts) != len(ts.Values) {
+			t.Errorf("%s: %v letters counted, %v valued", name, len(ts.Counts), len(ts.Values))
+		}
+		for letter := range ts.Counts {
+			if _, ok := ts.Values[letter]; !ok {
+				t.Errorf("%s: %s has no value", name, letter)
+			}
+		}
+		if ts.Counts["_"] != 2 || ts.Values["_"] != 0 {
+			t.Errorf("%s: %v blanks worth %v", name, ts.Counts["_"], ts.Values["_"])
+		}
+	}
+}
+
+func TestTileSetSplit(t *testing.T) {
+	tests := []struct {
+		ts    TileSet
+		input string
+		split []string
+		typed []string
+	}{
+		{SpanishTiles, "CHICO", []string{"CH", "I", "C", "O"}, []string{"CH", "I", "C", "O"}},
+		{SpanishTiles, "LLAMA", []string{"LL", "A", "M", "A"}, []string{"LL", "A", "M", "A"}},
+		{SpanishTiles, "chico", []string{"c", "h", "i", "c", "o"}, []string{"ch", "i", "c", "o"}},
+		{SpanishTiles, "Chico", []string{"C", "h", "i", "c", "o"}, []string{"C", "h", "i", "c", "o"}},
+		{SpanishTiles, "[C][H]ICO", []string{"[", "C", "]", "[", "H", "]", "I", "C", "O"}, []string{"[C]", "[H]", "I", "C", "O"}},
+		{SpanishTiles, "AÑO", []string{"A", "Ñ", "O"}, []string{"A", "Ñ", "O"}},
+		{EnglishTiles, "CHICO", []string{"C", "H", "I", "C", "O"}, []string{"C", "H", "I", "C", "O"}},
+	}
+	for _, tt := range tests {
+		if got := tt.ts.Split(tt.input); !reflect.DeepEqual(got, tt.split) {
+			t.Errorf("%s %s: split into %v, want %v", tt.ts.Name, tt.input, got, tt.split)
+		}
+		if got := tt.ts.splitInput(tt.input); !reflect.DeepEqual(got, tt.typed) {
+			t.Errorf("%s %s: typed as %v, want %v", tt.ts.Name, tt.input, got, tt.typed)
+		}
+	}
+}
+
+func TestWordListsUseTheirTiles(t *testing.T) {
+	for name, ts := range TileSets {
+		if ts.Dictionary == dictPath {
+			continue
+		}
+		dict, err := LoadTileSetDictionary(filepath.Join("..", ts.Dictionary), ts)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		for word := range dict.Words {
+			for _, letter := range ts.Split(word) {
+				if _, ok := ts.Counts[letter]; !ok {
+					t.Errorf("%s: %s is spelled with %s, not a tile of the set", name, word, letter)
+				}
+			}
+		}
+	}
+}
+
+func TestSpanishGame(t *testing.T) {
+	dict, err := LoadTileSetDictionary(filepath.Join("..", SpanishTiles.Dictionary), SpanishTiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bag := SpanishTiles.parseTiles(SpanishTiles.Split("CHICOAEDOSLLRREAN"))
+	if len(bag) != 14 || bag[0].Letter != "CH" || bag[0].Value != 5 || bag[9].Letter != "LL" {
+		t.Fatalf("bag read as %v", bag)
+	}
+	game := NewGameWithOptions([]PlayerRequest{{Name: "alice"}, {Name: "bob"}},
+		GameOptions{Seed: 1, Bag: bag, TileSet: &SpanishTiles, Dictionary: &dict, FixedOrder: true}, nil)
+	result, err := game.ApplyTurn("h8 CHICO", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// CH, I, C and O on four squares from the middle double word
+	if result.Score != 20 || result.Placed != 4 || boardLetter(game, 7, 7) != "CH" || boardLetter(game, 7, 10) != "O" {
+		t.Errorf("CHICO scored %v with %v tiles", result.Score, result.Placed)
+	}
+}
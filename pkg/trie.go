@@ -0,0 +1,144 @@
+package scrabble
+
+import (
+	"sort"
+	"sync"
+)
+
+// lexicon is the searchable form of a dictionary used to generate moves
+// built lazily since most interactions only need word lookups
+// letters are numbered by their position in the sorted alphabet
+// @anagrams set for the clabbers lexicon, whose nodes are expanded as they are reached
+type lexicon struct {
+	once     sync.Once
+	root     *trieNode
+	alphabet []string
+	letters  map[string]int
+	tiles    TileSet
+	multi    []string
+	anagrams *anagramIndex
+}
+
+// trieNode is a node in the prefix tree of every word in the dictionary
+type trieNode struct {
+	terminal bool
+	edges    []trieEdge
+}
+
+type trieEdge struct {
+	letter int
+	node   *trieNode
+}
+
+// child finds the node following the provided letter
+func (n *trieNode) child(letter int) *trieNode {
+	for _, e := range n.edges {
+		if e.letter == letter {
+			return e.node
+		}
+	}
+	return nil
+}
+
+// follow walks down the trie along the letters, nil when no word continues that way
+func (n *trieNode) follow(lex *lexicon, letters []string) *trieNode {
+	node := n
+	for _, l := range letters {
+		i, ok := lex.letters[l]
+		if !ok {
+			return nil
+		}
+		node = lex.child(node, i)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// edges returns the edges leaving a node of the lexicon
+func (lex *lexicon) edges(n *trieNode) []trieEdge {
+	if lex.anagrams != nil {
+		lex.anagrams.expand(n)
+	}
+	return n.edges
+}
+
+// child finds the node of the lexicon following the provided letter
+func (lex *lexicon) child(n *trieNode, letter int) *trieNode {
+	if lex.anagrams != nil {
+		lex.anagrams.expand(n)
+	}
+	return n.child(letter)
+}
+
+func (n *trieNode) insert(letters []int) {
+	node := n
+	for _, l := range letters {
+		next := node.child(l)
+		if next == nil {
+			next = &trieNode{}
+			node.edges = append(node.edges, trieEdge{letter: l, node: next})
+		}
+		node = next
+	}
+	node.terminal = true
+}
+
+// index returns the searchable lexicon of the dictionary, building it on first use
+func (d Dictionary) index() *lexicon {
+	lex := d.lex
+	if lex == nil {
+		// dictionaries built by hand are indexed on every call, use NewDictionary to share one
+		lex = &lexicon{}
+	}
+	lex.once.Do(func() {
+		lex.tiles = d.TileSet()
+		lex.multi = lex.tiles.multiLetters()
+		lex.letters = make(map[string]int)
+		for word := range d.Words {
+			for _, l := range lex.split(word) {
+				lex.letters[l] = 0
+			}
+		}
+		for l := range lex.letters {
+			lex.alphabet = append(lex.alphabet, l)
+		}
+		sort.Strings(lex.alphabet)
+		for i, l := range lex.alphabet {
+			lex.letters[l] = i
+		}
+
+		lex.root = &trieNode{}
+		for word := range d.Words {
+			lex.root.insert(lex.encode(word))
+		}
+		lex.root.sortEdges()
+	})
+	return lex
+}
+
+// encode converts a word into the numbered letters of the alphabet
+func (lex *lexicon) encode(word string) []int {
+	tokens := lex.split(word)
+	letters := make([]int, len(tokens))
+	for i, l := range tokens {
+		letters[i] = lex.letters[l]
+	}
+	return letters
+}
+
+// sortEdges orders the trie alphabetically so searches are deterministic
+func (n *trieNode) sortEdges() {
+	sort.Slice(n.edges, func(i, j int) bool {
+		return n.edges[i].letter < n.edges[j].letter
+	})
+	for _, e := range n.edges {
+		e.node.sortEdges()
+	}
+}
+
+// split breaks a word into the letters of individual tiles
+func (lex *lexicon) split(word string) []string {
+	return splitWord(word, lex.multi)
+}
@@ -0,0 +1,86 @@
+package scrabble
+
+import (
+	"reflect"
+	"testing"
+)
+
+// follow walks the trie along the letters, nil when a letter has no edge
+func follow(lex *lexicon, letters []string) *trieNode {
+	node := lex.root
+	for _, l := range letters {
+		letter, ok := lex.letters[l]
+		if !ok {
+			return nil
+		}
+		if node = node.child(letter); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func TestTrieFollow(t *testing.T) {
+	dict := Dictionary{Words: map[string]bool{"CAT": true, "CATS": true, "CAR": true, "DOG": true}}
+	lex := dict.index()
+	if !reflect.DeepEqual(lex.alphabet, []string{"A", "C", "D", "G", "O", "R", "S", "T"}) {
+		t.Errorf("alphabet: got %v", lex.alphabet)
+	}
+	tests := []struct {
+		letters  []string
+		found    bool
+		terminal bool
+	}{
+		{[]string{"C", "A"}, true, false},
+		{[]string{"C", "A", "T"}, true, true},
+		{[]string{"C", "A", "T", "S"}, true, true},
+		{[]string{"C", "A", "B"}, false, false},
+		{[]string{"C", "O"}, false, false},
+		{[]string{"D", "O", "G"}, true, true},
+		{[]string{"X"}, false, false},
+	}
+	for _, test := range tests {
+		node := follow(lex, test.letters)
+		if (node != nil) != test.found {
+			t.Errorf("%v: found %v, want %v", test.letters, node != nil, test.found)
+			continue
+		}
+		if node != nil && node.terminal != test.terminal {
+			t.Errorf("%v: terminal %v, want %v", test.letters, node.terminal, test.terminal)
+		}
+	}
+
+	// edges are sorted so searches are deterministic
+	var letters []string
+	for _, e := range follow(lex, []string{"C", "A"}).edges {
+		letters = append(letters, lex.alphabet[e.letter])
+	}
+	if !reflect.DeepEqual(letters, []string{"R", "T"}) {
+		t.Errorf("letters after CA: got %v", letters)
+	}
+}
+
+func TestTrieMultiLetterTiles(t *testing.T) {
+	dict := NewDictionary([]string{"CHICO", "LLAMA", "CALLE"})
+	dict.tiles = &SpanishTiles
+	lex := dict.index()
+	tests := map[string][]string{
+		"CHICO": {"CH", "I", "C", "O"},
+		"LLAMA": {"LL", "A", "M", "A"},
+		"CALLE": {"C", "A", "LL", "E"},
+	}
+	for word, want := range tests {
+		if got := lex.split(word); !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: split into %v, want %v", word, got, want)
+		}
+		if node := follow(lex, want); node == nil || !node.terminal {
+			t.Errorf("%v: not found in the trie", word)
+		}
+	}
+	if _, ok := lex.letters["H"]; ok {
+		t.Error("H only appears within CH and should not be a letter of the alphabet")
+	}
+	if node := follow(lex, []string{"C", "H"}); node != nil {
+		t.Error("C followed by H should not spell CH")
+	}
+}
@@ -0,0 +1,126 @@
+package scrabble
+
+import (
+	"fmt"
+	"sort"
+)
+
+// vowels of the english tiles used to balance racks and summarize unseen tiles
+var vowels = map[string]bool{
+	"A": true,
+	"E": true,
+	"I": true,
+	"O": true,
+	"U": true,
+}
+
+// UnseenTiles represents every tile a player has not yet seen
+// which is the tiles in the bag plus the tiles on opponents racks
+// @Counts number of each letter unseen, blanks are counted under "_"
+type UnseenTiles struct {
+	Counts     map[string]int `json:"counts"`
+	Vowels     int            `json:"vowels"`
+	Consonants int            `json:"consonants"`
+	Blanks     int            `json:"blanks"`
+	Total      int            `json:"total"`
+	tiles      TileSet
+}
+
+// Unseen returns the tiles not visible to the provided player
+func (game *Game) Unseen(player Player) UnseenTiles {
+	return CountUnseen(game.board, player.tiles, game.TileSet())
+}
+
+// CountUnseen takes the full tile distribution and removes the tiles on the board and in the rack
+func CountUnseen(board Board, rack []Tile, ts TileSet) UnseenTiles {
+	counts := make(map[string]int)
+	for letter, count := range ts.Counts {
+		counts[letter] = count
+	}
+
+	for _, row := range board {
+		for _, s := range row {
+			if s.IsEmpty() {
+				continue
+			}
+			counts[tileKey(s.Value)]--
+		}
+	}
+	for _, t := range rack {
+		counts[tileKey(t)]--
+	}
+	return summarizeUnseen(counts, ts)
+}
+
+// UnseenCounts summarizes the unseen tiles of a position
+func (pos Position) UnseenCounts() UnseenTiles {
+	ts, _, _ := pos.Rules.orDefault().TileSetLayout()
+	counts := make(map[string]int)
+	for _, t := range pos.Unseen {
+		counts[tileKey(t)]++
+	}
+	return summarizeUnseen(counts, ts)
+}
+
+// summarizeUnseen totals the counts of unseen letters by kind
+func summarizeUnseen(counts map[string]int, ts TileSet) UnseenTiles {
+	unseen := UnseenTiles{Counts: counts, tiles: ts}
+	for letter, count := range counts {
+		// guard against positions holding more tiles than the distribution
+		if count < 0 {
+			counts[letter] = 0
+			count = 0
+		}
+		switch {
+		case letter == "_":
+			unseen.Blanks += count
+		case ts.Vowels[letter]:
+			unseen.Vowels += count
+		default:
+			unseen.Consonants += count
+		}
+		unseen.Total += count
+	}
+	return unseen
+}
+
+// Tiles expands the unseen counts into individual tiles
+func (u UnseenTiles) Tiles() []Tile {
+	var tiles []Tile
+	for _, letter := range u.letters() {
+		for i := 0; i < u.Counts[letter]; i++ {
+			tiles = append(tiles, u.tiles.Tile(letter))
+		}
+	}
+	return tiles
+}
+
+func (u UnseenTiles) letters() []string {
+	var letters []string
+	for letter := range u.Counts {
+		letters = append(letters, letter)
+	}
+	sort.Strings(letters)
+	return letters
+}
+
+func (u UnseenTiles) String() string {
+	var str string
+	for _, letter := range u.letters() {
+		if u.Counts[letter] == 0 {
+			continue
+		}
+		str = fmt.Sprintf("%s%s:%v ", str, letter, u.Counts[letter])
+	}
+	return fmt.Sprintf("%s\nUnseen: %v, Vowels: %v, Consonants: %v, Blanks: %v",
+		str, u.Total, u.Vowels, u.Consonants, u.Blanks)
+}
+
+// tileKey returns the distribution letter a tile was drawn as
+// played blanks carry their assigned letter but came from a blank tile
+func tileKey(t Tile) string {
+	if t.IsBlank {
+		return "_"
+	}
+	return t.Letter
+}
@@ -0,0 +1,34 @@
+package scrabble
+
+import "testing"
+
+func TestUnseenTiles(t *testing.T) {
+	game := newTestGame(t, "CAT?EEEDOGSRRR", "CAT")
+	alice := game.CurrentPlayer()
+	unseen := game.Unseen(alice)
+	if unseen.Total != 93 || unseen.Blanks != 1 || unseen.Counts["E"] != 9 {
+		t.Errorf("before any play alice has %v unseen, %v blanks and %v E", unseen.Total, unseen.Blanks, unseen.Counts["E"])
+	}
+
+	if _, err := game.ApplyTurn("place c(h,8) _a(h,9) t(h,10)", nil); err != nil {
+		t.Fatal(err)
+	}
+	bob := game.CurrentPlayer()
+	unseen = game.Unseen(bob)
+	// the board holds C, T and a blank, bob holds DOGSRRR
+	for letter, want := range map[string]int{"C": 1, "T": 5, "A": 9, "_": 1, "D": 3, "O": 7, "R": 3, "Z": 1} {
+		if unseen.Counts[letter] != want {
+			t.Errorf("%s: %v unseen, want %v", letter, unseen.Counts[letter], want)
+		}
+	}
+	if unseen.Total != 90 || unseen.Vowels != 41 || unseen.Consonants != 48 || unseen.Blanks != 1 {
+		t.Errorf("got %v unseen, %v vowels, %v consonants and %v blanks, want 90, 41, 48 and 1",
+			unseen.Total, unseen.Vowels, unseen.Consonants, unseen.Blanks)
+	}
+	if n := len(unseen.Tiles()); n != unseen.Total {
+		t.Errorf("%v tiles expanded from %v unseen", n, unseen.Total)
+	}
+	if pos := game.Position(); len(pos.Unseen) != unseen.Total {
+		t.Errorf("the position of bob holds %v unseen, the game %v", len(pos.Unseen), unseen.Total)
+	}
+}
@@ -17,6 +17,15 @@ func (w Word) String() string {
 	return word
 }
 
+// Letters returns the letter of every tile of the word in order
+func (w Word) Letters() []string {
+	letters := make([]string, len(w.Squares))
+	for i, s := range w.Squares {
+		letters[i] = s.Value.Letter
+	}
+	return letters
+}
+
 // ScoreWord calculates the value of the word
 func (w Word) ScoreWord() int {
 	var total int